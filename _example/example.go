@@ -1,3 +1,5 @@
+//go:generate go run ../cmd/spoongen
+
 package example
 
 import (
@@ -30,7 +32,7 @@ func (u *User) TableName() string {
 }
 
 func (u *User) PrimaryKey() *spoon.PrimaryKey {
-	return spoon.AddPrimaryKey(spoon.KeyPart{ColumnName: "ID"})
+	return spoon.AddPrimaryKey(UserColumns.ID.Asc())
 }
 
 func (u *User) Indexes() spoon.Indexes {
@@ -56,7 +58,7 @@ func (e Entry) PrimaryKey() *spoon.PrimaryKey {
 
 func (e Entry) Indexes() spoon.Indexes {
 	return spoon.Indexes{
-		spoon.AddIndex("EntryByTitle", "Entry", false, spoon.KeyPart{ColumnName: "Title"}),
+		spoon.AddIndex("EntryByTitle", "Entry", false, EntryColumns.Title.Asc()),
 	}
 }
 
@@ -103,7 +105,7 @@ func (b Bookmark) PrimaryKey() *spoon.PrimaryKey {
 
 func (b Bookmark) Indexes() spoon.Indexes {
 	return spoon.Indexes{
-		spoon.AddUniqueIndex("BookmarkByUserIDEntryID", "BookMark", false, spoon.KeyPart{ColumnName: "UserID"}, spoon.KeyPart{ColumnName: "EntryID", IsOrderDesc: true}),
+		spoon.AddUniqueIndex("BookmarkByUserIDEntryID", "Bookmark", false, spoon.KeyPart{ColumnName: "UserID"}, spoon.KeyPart{ColumnName: "EntryID", IsOrderDesc: true}),
 	}
 }
 
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/pi9min/spoon"
+	ex "github.com/pi9min/spoon/_example"
+)
+
+func main() {
+	var (
+		outFilePath string
+	)
+	flag.StringVar(&outFilePath, "o", "./_example/sql/schema.sql", "set ddl output file path")
+	flag.StringVar(&outFilePath, "outfile", "./_example/sql/schema.sql", "set ddl output file path")
+	flag.Parse()
+
+	if outFilePath == "" {
+		log.Println("Please set outFilePath. -o or -outfile")
+		return
+	}
+
+	cli, err := spoon.New()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	ebs := []spoon.EntityBehavior{
+		&ex.User{},
+		ex.Entry{},
+		ex.PlayerComment{},
+		ex.Bookmark{},
+		ex.Balance{},
+		ex.NestParent{},
+	}
+
+	f, err := os.Create(outFilePath)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer f.Close()
+
+	if err := cli.WriteSchema(f, ebs, spoon.Header("Code generated by spoon. DO NOT EDIT."), spoon.TrailingComma(false)); err != nil {
+		log.Println(err.Error())
+		return
+	}
+}
@@ -0,0 +1,103 @@
+// Code generated by spoongen; DO NOT EDIT.
+
+package example
+
+import (
+	"github.com/pi9min/spoon"
+)
+
+// UserColumns holds the column names of User.
+var UserColumns = struct {
+	ID         spoon.ColumnName
+	Name       spoon.ColumnName
+	Token      spoon.ColumnName
+	BornedDate spoon.ColumnName
+	CreatedAt  spoon.ColumnName
+	UpdatedAt  spoon.ColumnName
+}{
+	ID:         "ID",
+	Name:       "Name",
+	Token:      "Token",
+	BornedDate: "BornedDate",
+	CreatedAt:  "CreatedAt",
+	UpdatedAt:  "UpdatedAt",
+}
+
+// EntryColumns holds the column names of Entry.
+var EntryColumns = struct {
+	ID        spoon.ColumnName
+	Title     spoon.ColumnName
+	Public    spoon.ColumnName
+	Content   spoon.ColumnName
+	CreatedAt spoon.ColumnName
+	UpdatedAt spoon.ColumnName
+}{
+	ID:        "ID",
+	Title:     "Title",
+	Public:    "Public",
+	Content:   "Content",
+	CreatedAt: "CreatedAt",
+	UpdatedAt: "UpdatedAt",
+}
+
+// PlayerCommentColumns holds the column names of PlayerComment.
+var PlayerCommentColumns = struct {
+	ID        spoon.ColumnName
+	PlayerID  spoon.ColumnName
+	EntryID   spoon.ColumnName
+	Comment   spoon.ColumnName
+	CreatedAt spoon.ColumnName
+	updatedAt spoon.ColumnName
+}{
+	ID:        "ID",
+	PlayerID:  "PlayerID",
+	EntryID:   "EntryID",
+	Comment:   "Comment",
+	CreatedAt: "CreatedAt",
+	updatedAt: "updatedAt",
+}
+
+// BookmarkColumns holds the column names of Bookmark.
+var BookmarkColumns = struct {
+	ID        spoon.ColumnName
+	UserID    spoon.ColumnName
+	EntryID   spoon.ColumnName
+	Comments  spoon.ColumnName
+	CreatedAt spoon.ColumnName
+	UpdatedAt spoon.ColumnName
+}{
+	ID:        "ID",
+	UserID:    "UserID",
+	EntryID:   "EntryID",
+	Comments:  "Comments",
+	CreatedAt: "CreatedAt",
+	UpdatedAt: "UpdatedAt",
+}
+
+// BalanceColumns holds the column names of Balance.
+var BalanceColumns = struct {
+	ID         spoon.ColumnName
+	UserID     spoon.ColumnName
+	CurrencyID spoon.ColumnName
+	Amount     spoon.ColumnName
+}{
+	ID:         "ID",
+	UserID:     "UserID",
+	CurrencyID: "CurrencyID",
+	Amount:     "Amount",
+}
+
+// NestParentColumns holds the column names of NestParent.
+var NestParentColumns = struct {
+	NC1ID     spoon.ColumnName
+	NestedAt  spoon.ColumnName
+	NC2ID     spoon.ColumnName
+	Birthdate spoon.ColumnName
+	Nested2At spoon.ColumnName
+}{
+	NC1ID:     "NC1ID",
+	NestedAt:  "NestedAt",
+	NC2ID:     "NC2ID",
+	Birthdate: "Birthdate",
+	Nested2At: "Nested2At",
+}
@@ -0,0 +1,75 @@
+// Package analysis provides the analyzer that checks the spoon Entity, which is run by cmd/spoonvet.
+package analysis
+
+import (
+	"go/types"
+
+	"github.com/pi9min/spoon/internal/bridge"
+	"github.com/pi9min/spoon/internal/source"
+	"golang.org/x/tools/go/analysis"
+)
+
+const spoonPkgPath = "github.com/pi9min/spoon"
+
+// Analyzer reports the mistakes of the Entity that are otherwise caught at generation time, if at all,
+// such as an unknown struct tag, a key part of the column that does not exist and an index of another table.
+// It checks the types that implement spoon.EntityBehavior, and can be used by `go vet -vettool` through cmd/spoonvet.
+var Analyzer = &analysis.Analyzer{
+	Name: "spoon",
+	Doc:  "check the struct tags and the key declarations of spoon Entity",
+	Run:  run,
+}
+
+var (
+	tagPrefix string
+	ignoreTag string
+)
+
+func init() {
+	Analyzer.Flags.StringVar(&tagPrefix, "tag", "db", "set the struct tag prefix")
+	Analyzer.Flags.StringVar(&ignoreTag, "ignore", "-", "set the tag value that ignores the field")
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	iface := entityBehavior(pass.Pkg)
+	if iface == nil {
+		// The package does not import spoon.
+		return nil, nil
+	}
+
+	sp := source.NewParser(bridge.NewParser(tagPrefix, ignoreTag), pass.Fset, pass.Pkg, pass.TypesInfo, pass.Files)
+	sp.Report = func(e *source.Error) {
+		pass.Report(analysis.Diagnostic{Pos: e.Pos, Message: e.Err.Error()})
+	}
+	for _, tn := range sp.Entities() {
+		if types.Implements(tn.Type(), iface) || types.Implements(types.NewPointer(tn.Type()), iface) {
+			sp.Check(tn)
+		}
+	}
+
+	return nil, nil
+}
+
+// entityBehavior returns the EntityBehavior interface of spoon package imported by pkg, or nil.
+func entityBehavior(pkg *types.Package) *types.Interface {
+	spoon := pkg
+	if pkg.Path() != spoonPkgPath {
+		spoon = nil
+		for _, imp := range pkg.Imports() {
+			if imp.Path() == spoonPkgPath {
+				spoon = imp
+			}
+		}
+	}
+	if spoon == nil {
+		return nil
+	}
+
+	obj, ok := spoon.Scope().Lookup("EntityBehavior").(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	iface, _ := obj.Type().Underlying().(*types.Interface)
+
+	return iface
+}
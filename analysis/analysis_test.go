@@ -0,0 +1,12 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/pi9min/spoon/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, "testdata/analyzer", analysis.Analyzer, "./entity")
+}
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pi9min/spoon"
+)
+
+type User struct {
+	ID        int64
+	Name      string `db:"size:99"` // want `field Name: unknown tag "size:99"`
+	Age       int64  `db:"size=10"` // want `field Age: size can only be used for STRING or BYTES column, but INT64`
+	Token     string `db:"desc"`    // want `field Token: desc can only be used with pk`
+	CreatedAt time.Time
+}
+
+func (User) TableName() string {
+	return "User"
+}
+
+func (User) PrimaryKey() *spoon.PrimaryKey {
+	return spoon.AddPrimaryKey(spoon.KeyPart{ColumnName: "Id"}) // want `primary key: column Id does not exist`
+}
+
+func (User) Indexes() spoon.Indexes {
+	return spoon.Indexes{
+		spoon.AddIndex("UserByName", "Users", false, spoon.KeyPart{ColumnName: "Name"}),                             // want `index UserByName: table Users does not match TableName\(\) User`
+		spoon.AddIndex("UserByCreatedAt", "User", false, UserColumns.CreatedAt.Desc(), UserColumns.UpdatedAt.Asc()), // want `index UserByCreatedAt: column UpdatedAt does not exist`
+	}
+}
+
+var UserColumns = struct {
+	ID        spoon.ColumnName
+	CreatedAt spoon.ColumnName
+	UpdatedAt spoon.ColumnName
+}{"ID", "CreatedAt", "UpdatedAt"}
+
+type Entry struct { // want `table Entry: primary key is not declared`
+	ID int64
+}
+
+func (*Entry) TableName() string {
+	return "Entry"
+}
+
+func (*Entry) PrimaryKey() *spoon.PrimaryKey {
+	return nil
+}
+
+func (*Entry) Indexes() spoon.Indexes {
+	return nil
+}
+
+// Dynamic is not checked as a whole since TableName can not be evaluated statically.
+type Dynamic struct {
+	ID int64
+}
+
+func (Dynamic) TableName() string {
+	return strings.ToUpper("dynamic")
+}
+
+func (Dynamic) PrimaryKey() *spoon.PrimaryKey {
+	return nil
+}
+
+func (Dynamic) Indexes() spoon.Indexes {
+	return nil
+}
+
+// NotEntity is not checked since it does not implement spoon.EntityBehavior.
+type NotEntity struct {
+	Name string `db:"column=name"`
+}
+
+func (NotEntity) TableName() string {
+	return "not_entity"
+}
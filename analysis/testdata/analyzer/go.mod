@@ -0,0 +1,14 @@
+module example.com/analyzer
+
+go 1.22.0
+
+require github.com/pi9min/spoon v0.0.0
+
+require (
+	github.com/pkg/errors v0.8.0 // indirect
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
+	golang.org/x/tools v0.30.0 // indirect
+)
+
+replace github.com/pi9min/spoon => ../../..
@@ -0,0 +1,292 @@
+package spoon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Backend is the database other than Cloud Spanner that the approximation of the schema is written for,
+// such as the local database of the unit tests.
+type Backend int
+
+const (
+	// BackendPostgreSQL is the plain PostgreSQL.
+	BackendPostgreSQL Backend = iota + 1
+	// BackendSQLite is SQLite.
+	BackendSQLite
+)
+
+// String returns the name of the backend.
+func (b Backend) String() string {
+	switch b {
+	case BackendPostgreSQL:
+		return "PostgreSQL"
+	case BackendSQLite:
+		return "SQLite"
+	}
+
+	return fmt.Sprintf("Backend(%d)", int(b))
+}
+
+var backendTypeMappings = map[Backend]*typeMapping{
+	BackendPostgreSQL: {
+		names: map[baseType]string{
+			typeBool:      "boolean",
+			typeInt64:     "bigint",
+			typeFloat64:   "double precision",
+			typeString:    "varchar",
+			typeBytes:     "bytea",
+			typeDate:      "date",
+			typeTimestamp: "timestamptz",
+			typeJSON:      "jsonb",
+			typeNumeric:   "numeric",
+		},
+		sized:   map[baseType]bool{typeString: true},
+		unsized: map[baseType]string{typeString: "text"},
+		array: func(elem string) string {
+			return elem + "[]"
+		},
+	},
+	BackendSQLite: {
+		names: map[baseType]string{
+			typeBool:      "INTEGER",
+			typeInt64:     "INTEGER",
+			typeFloat64:   "REAL",
+			typeString:    "TEXT",
+			typeBytes:     "BLOB",
+			typeDate:      "TEXT",
+			typeTimestamp: "TEXT",
+			typeJSON:      "TEXT",
+			typeNumeric:   "NUMERIC",
+		},
+		// SQLite has no array type, so the array is stored as JSON text.
+		array: func(string) string {
+			return "TEXT"
+		},
+	},
+}
+
+// Warning reports the Spanner feature that is dropped or changed in the translation to Backend.
+type Warning struct {
+	// Object is the name of the object that has the feature.
+	Object  string
+	Message string
+}
+
+// String returns the warning as `<object>: <message>`.
+func (w Warning) String() string {
+	return w.Object + ": " + w.Message
+}
+
+// Warnings are alias of warning slices.
+type Warnings []Warning
+
+// GenerateBackendSchema outputs the schema of the specified Entity translated to the backend as a string slices,
+// and the warnings of the Spanner features that are dropped in the translation.
+func (c *Client) GenerateBackendSchema(b Backend, ebs []EntityBehavior, opts ...FormatOption) ([]string, Warnings, error) {
+	s, err := c.BuildSchema(ebs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return s.backendDDL(b, c.format(opts...))
+}
+
+// BackendDDL returns the schema translated to the backend as a string slices,
+// and the warnings of the Spanner features that are dropped in the translation.
+// The types are mapped to the nearest equivalent, and the interleaved table has the foreign key
+// to its parent with `ON DELETE CASCADE`.
+func (s *Schema) BackendDDL(b Backend, opts ...FormatOption) ([]string, Warnings, error) {
+	return s.backendDDL(b, newFormat(opts))
+}
+
+func (s *Schema) backendDDL(b Backend, f *format) ([]string, Warnings, error) {
+	types, ok := backendTypeMappings[b]
+	if !ok {
+		return nil, nil, errors.Errorf("unknown backend %s", b)
+	}
+
+	tr := &translator{backend: b, format: f, schema: s, types: types}
+	return tr.translate(), tr.warnings, nil
+}
+
+// translator translates Schema to the DDL of Backend collecting the warnings.
+type translator struct {
+	backend  Backend
+	format   *format
+	schema   *Schema
+	types    *typeMapping
+	warnings Warnings
+}
+
+func (tr *translator) warn(object, msg string, args ...interface{}) {
+	tr.warnings = append(tr.warnings, Warning{Object: object, Message: fmt.Sprintf(msg, args...)})
+}
+
+func (tr *translator) translate() []string {
+	s := tr.schema
+	var ss []string
+	if s.dbOptions != (DatabaseOptions{}) {
+		tr.warn(s.dbName, "database options are dropped")
+	}
+	for _, ns := range s.NamedSchemas() {
+		if tr.backend == BackendSQLite {
+			tr.warn(ns.name, "named schema is dropped and the tables are created without the schema name")
+			continue
+		}
+		ss = append(ss, fmt.Sprintf("%s %s", tr.format.createClause("SCHEMA"), tr.quote(ns.name)))
+	}
+	for _, seq := range s.sequences {
+		if sql := tr.sequence(seq); sql != "" {
+			ss = append(ss, sql)
+		}
+	}
+	for _, lg := range s.localityGroups {
+		tr.warn(lg.name, "locality group is dropped")
+	}
+	for _, t := range s.tables {
+		ss = append(ss, tr.table(t))
+	}
+	for _, idx := range s.Indexes() {
+		ss = append(ss, tr.index(idx))
+	}
+	for _, r := range s.roles {
+		tr.warn(r.name, "role and its grants are dropped")
+	}
+
+	return ss
+}
+
+// quote quotes the identifier. The schema name is dropped in SQLite.
+func (tr *translator) quote(name string) string {
+	if tr.backend == BackendSQLite {
+		name = name[strings.LastIndex(name, ".")+1:]
+	}
+
+	return PostgreSQL.quote(name)
+}
+
+func (tr *translator) sequence(seq *Sequence) string {
+	if tr.backend == BackendSQLite {
+		tr.warn(seq.name, "sequence is dropped")
+		return ""
+	}
+
+	tr.warn(seq.name, "bit-reversed sequence is translated to ascending sequence")
+	sql := fmt.Sprintf("%s %s", tr.format.createClause("SEQUENCE"), tr.quote(seq.name))
+	if seq.startWithCounter != 0 {
+		sql += fmt.Sprintf(" START WITH %d", seq.startWithCounter)
+	}
+
+	return sql
+}
+
+func (tr *translator) table(t *Table) string {
+	defs := make([]string, 0, len(t.columns)+2)
+	for _, c := range t.columns {
+		defs = append(defs, tr.column(t, c))
+	}
+	defs = append(defs, tr.primaryKey(t))
+	if parent, ok := tr.schema.tableByName[t.primaryKey.interleavedTableName]; ok {
+		cols := make([]string, 0, len(parent.primaryKey.keyParts))
+		for _, col := range parent.primaryKey.columnNames() {
+			cols = append(cols, tr.quote(col))
+		}
+		defs = append(defs, fmt.Sprintf(
+			"FOREIGN KEY (%s) REFERENCES %s (%s) ON DELETE CASCADE",
+			strings.Join(cols, ", "),
+			tr.quote(parent.name),
+			strings.Join(cols, ", "),
+		))
+	}
+	if t.localityGroup != "" {
+		tr.warn(t.name, "locality group %s is dropped", t.localityGroup)
+	}
+
+	ss := make([]string, 0, len(defs)+2)
+	ss = append(ss, fmt.Sprintf("%s %s (", tr.format.createClause("TABLE"), tr.quote(t.name)))
+	for i, def := range defs {
+		if i == len(defs)-1 {
+			ss = append(ss, tr.format.indentString()+def)
+			continue
+		}
+		ss = append(ss, tr.format.indentString()+def+",")
+	}
+	ss = append(ss, ")")
+
+	return strings.Join(ss, "\n")
+}
+
+func (tr *translator) column(t *Table, c *Column) string {
+	ct, tNull := c.columnType()
+	if ct.array && tr.backend == BackendSQLite {
+		tr.warn(t.name+"."+c.name, "array is stored as TEXT")
+	}
+
+	sql := fmt.Sprintf("%s %s", tr.quote(c.name), tr.types.typeString(ct))
+	if !(c.isNull || tNull) {
+		sql += " NOT NULL"
+	}
+	if c.sequenceName != "" {
+		if tr.backend == BackendSQLite {
+			tr.warn(t.name+"."+c.name, "default value of sequence %s is dropped", c.sequenceName)
+		} else {
+			sql += " DEFAULT " + nextval(tr.quote(c.sequenceName))
+		}
+	}
+	if c.localityGroup != "" {
+		tr.warn(t.name+"."+c.name, "locality group %s is dropped", c.localityGroup)
+	}
+
+	return sql
+}
+
+func (tr *translator) primaryKey(t *Table) string {
+	keyParts := make([]string, 0, len(t.primaryKey.keyParts))
+	for _, kp := range t.primaryKey.keyParts {
+		kps := tr.quote(kp.ColumnName)
+		if kp.IsOrderDesc {
+			// PostgreSQL does not order the primary key.
+			if tr.backend == BackendPostgreSQL {
+				tr.warn(t.name, "descending order of primary key column %s is dropped", kp.ColumnName)
+			} else {
+				kps += " DESC"
+			}
+		}
+		keyParts = append(keyParts, kps)
+	}
+
+	return fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(keyParts, ", "))
+}
+
+func (tr *translator) index(idx *Index) string {
+	keyParts := make([]string, 0, len(idx.keyParts))
+	conds := make([]string, 0, len(idx.keyParts))
+	for _, kp := range idx.keyParts {
+		kps := tr.quote(kp.ColumnName)
+		conds = append(conds, kps+" IS NOT NULL")
+		if kp.IsOrderDesc {
+			kps += " DESC"
+		}
+		keyParts = append(keyParts, kps)
+	}
+
+	object := "INDEX"
+	if idx.isUnique {
+		object = "UNIQUE INDEX"
+	}
+	// The index is created in the schema of the table, so the name can not be qualified.
+	name := idx.name[strings.LastIndex(idx.name, ".")+1:]
+	sql := fmt.Sprintf("%s %s ON %s (%s)", tr.format.createClause(object), PostgreSQL.quote(name), tr.quote(idx.tableName), strings.Join(keyParts, ", "))
+	// The null filtered index is translated to the partial index.
+	if idx.nullFiltered {
+		sql += " WHERE " + strings.Join(conds, " AND ")
+	}
+
+	return sql
+}
@@ -0,0 +1,116 @@
+package spoon_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+func TestGenerateBackendSchema(t *testing.T) {
+	tests := []struct {
+		name           string
+		backend        spoon.Backend
+		opts           []spoon.Option
+		entity         []spoon.EntityBehavior
+		expect         []string
+		expectWarnings spoon.Warnings
+	}{
+		{
+			name:    "1 PostgreSQL interleave",
+			backend: spoon.BackendPostgreSQL,
+			entity:  []spoon.EntityBehavior{&Test2{}, Test1{}},
+			expect: []string{
+				"CREATE TABLE \"Test1\" (\n    \"ID\" bigint NOT NULL,\n    \"Name\" text NOT NULL,\n    \"CreatedAt\" timestamptz NOT NULL,\n    \"UpdatedAt\" timestamptz NOT NULL,\n    PRIMARY KEY (\"ID\")\n)",
+				"CREATE TABLE \"Test2\" (\n    \"ID\" bigint NOT NULL,\n    \"Test1ID\" bigint NOT NULL,\n    \"Comment\" varchar(1024),\n    \"CreatedAt\" timestamptz NOT NULL,\n    \"UpdatedAt\" timestamptz NOT NULL,\n    PRIMARY KEY (\"ID\", \"CreatedAt\"),\n    FOREIGN KEY (\"ID\") REFERENCES \"Test1\" (\"ID\") ON DELETE CASCADE\n)",
+				`CREATE INDEX "Test1ByCreatedAtDesc" ON "Test1" ("CreatedAt" DESC)`,
+			},
+			expectWarnings: spoon.Warnings{
+				{Object: "Test2", Message: "descending order of primary key column CreatedAt is dropped"},
+			},
+		},
+		{
+			name:    "2 SQLite interleave",
+			backend: spoon.BackendSQLite,
+			entity:  []spoon.EntityBehavior{&Test2{}, Test1{}},
+			expect: []string{
+				"CREATE TABLE \"Test1\" (\n    \"ID\" INTEGER NOT NULL,\n    \"Name\" TEXT NOT NULL,\n    \"CreatedAt\" TEXT NOT NULL,\n    \"UpdatedAt\" TEXT NOT NULL,\n    PRIMARY KEY (\"ID\")\n)",
+				"CREATE TABLE \"Test2\" (\n    \"ID\" INTEGER NOT NULL,\n    \"Test1ID\" INTEGER NOT NULL,\n    \"Comment\" TEXT,\n    \"CreatedAt\" TEXT NOT NULL,\n    \"UpdatedAt\" TEXT NOT NULL,\n    PRIMARY KEY (\"ID\", \"CreatedAt\" DESC),\n    FOREIGN KEY (\"ID\") REFERENCES \"Test1\" (\"ID\") ON DELETE CASCADE\n)",
+				`CREATE INDEX "Test1ByCreatedAtDesc" ON "Test1" ("CreatedAt" DESC)`,
+			},
+		},
+		{
+			name:    "3 PostgreSQL Spanner features",
+			backend: spoon.BackendPostgreSQL,
+			opts: []spoon.Option{
+				spoon.WithSequences(spoon.AddSequence("Test3Seq")),
+				spoon.WithRoles(spoon.AddRole("analyst"), spoon.AddRole("writer")),
+				spoon.WithLocalityGroups(spoon.AddLocalityGroup("cold", spoon.StorageHDD), spoon.AddLocalityGroupWithSpill("spill", "10d")),
+			},
+			entity: []spoon.EntityBehavior{&Test3{}, &Test5{}, &Test7{}, spoon.Entity(&Test7Parent{}), &Test8{}},
+			expect: []string{
+				`CREATE SCHEMA "sales"`,
+				`CREATE SEQUENCE "Test3Seq"`,
+				"CREATE TABLE \"Test3\" (\n    \"ID\" bigint NOT NULL DEFAULT nextval('\"Test3Seq\"'),\n    \"Name\" text NOT NULL,\n    PRIMARY KEY (\"ID\")\n)",
+				"CREATE TABLE \"Test5\" (\n    \"ID\" bigint NOT NULL,\n    \"Name\" text NOT NULL,\n    \"Amount\" bigint NOT NULL,\n    PRIMARY KEY (\"ID\")\n)",
+				"CREATE TABLE \"sales\".\"Customers\" (\n    \"CustomerID\" bigint NOT NULL,\n    PRIMARY KEY (\"CustomerID\")\n)",
+				"CREATE TABLE \"sales\".\"Orders\" (\n    \"CustomerID\" bigint NOT NULL,\n    \"OrderID\" bigint NOT NULL,\n    \"Amount\" bigint NOT NULL,\n    PRIMARY KEY (\"CustomerID\", \"OrderID\"),\n    FOREIGN KEY (\"CustomerID\") REFERENCES \"sales\".\"Customers\" (\"CustomerID\") ON DELETE CASCADE\n)",
+				"CREATE TABLE \"Test8\" (\n    \"ID\" bigint NOT NULL,\n    \"Blob\" bytea NOT NULL,\n    PRIMARY KEY (\"ID\")\n)",
+				`CREATE INDEX "Test3ByName" ON "Test3" ("Name")`,
+				`CREATE INDEX "OrdersByAmount" ON "sales"."Orders" ("Amount")`,
+			},
+			expectWarnings: spoon.Warnings{
+				{Object: "Test3Seq", Message: "bit-reversed sequence is translated to ascending sequence"},
+				{Object: "cold", Message: "locality group is dropped"},
+				{Object: "spill", Message: "locality group is dropped"},
+				{Object: "Test8.Blob", Message: "locality group cold is dropped"},
+				{Object: "Test8", Message: "locality group spill is dropped"},
+				{Object: "analyst", Message: "role and its grants are dropped"},
+				{Object: "writer", Message: "role and its grants are dropped"},
+			},
+		},
+		{
+			name:    "4 SQLite Spanner features",
+			backend: spoon.BackendSQLite,
+			opts:    []spoon.Option{spoon.WithSequences(spoon.AddSequence("Test3Seq"), spoon.AddSequence("Test9Seq"))},
+			entity:  []spoon.EntityBehavior{&Test3{}, &Test7{}, spoon.Entity(&Test7Parent{}), spoon.Entity(&Test9{})},
+			expect: []string{
+				"CREATE TABLE \"Test3\" (\n    \"ID\" INTEGER NOT NULL,\n    \"Name\" TEXT NOT NULL,\n    PRIMARY KEY (\"ID\")\n)",
+				"CREATE TABLE \"Customers\" (\n    \"CustomerID\" INTEGER NOT NULL,\n    PRIMARY KEY (\"CustomerID\")\n)",
+				"CREATE TABLE \"Orders\" (\n    \"CustomerID\" INTEGER NOT NULL,\n    \"OrderID\" INTEGER NOT NULL,\n    \"Amount\" INTEGER NOT NULL,\n    PRIMARY KEY (\"CustomerID\", \"OrderID\"),\n    FOREIGN KEY (\"CustomerID\") REFERENCES \"Customers\" (\"CustomerID\") ON DELETE CASCADE\n)",
+				"CREATE TABLE \"Test9\" (\n    \"ID\" INTEGER NOT NULL,\n    \"Name\" TEXT NOT NULL,\n    \"Tags\" TEXT NOT NULL,\n    \"Note\" TEXT,\n    \"CreatedAt\" TEXT NOT NULL,\n    PRIMARY KEY (\"ID\")\n)",
+				`CREATE INDEX "Test3ByName" ON "Test3" ("Name")`,
+				`CREATE INDEX "OrdersByAmount" ON "Orders" ("Amount")`,
+				`CREATE INDEX "Test9ByNote" ON "Test9" ("Note")`,
+			},
+			expectWarnings: spoon.Warnings{
+				{Object: "sales", Message: "named schema is dropped and the tables are created without the schema name"},
+				{Object: "Test3Seq", Message: "sequence is dropped"},
+				{Object: "Test9Seq", Message: "sequence is dropped"},
+				{Object: "Test3.ID", Message: "default value of sequence Test3Seq is dropped"},
+				{Object: "Test9.ID", Message: "default value of sequence Test9Seq is dropped"},
+				{Object: "Test9.Tags", Message: "array is stored as TEXT"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli, err := spoon.New(tt.opts...)
+			if err != nil {
+				t.Fatalf("error new Client")
+			}
+
+			actual, warnings, err := cli.GenerateBackendSchema(tt.backend, tt.entity)
+			if err != nil {
+				t.Fatalf("error GenerateBackendSchema: %v", err)
+			}
+			if diff := cmp.Diff(tt.expect, actual); diff != "" {
+				t.Errorf("Diff:\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.expectWarnings, warnings); diff != "" {
+				t.Errorf("Warnings Diff:\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,7 +2,63 @@ package spoon
 
 // EntityBehavior defines the interface that needs to be satisfied as an Entity.
 type EntityBehavior interface {
+	TableBehavior
+	PrimaryKeyBehavior
+	IndexBehavior
+}
+
+// TableBehavior defines the minimum interface of an Entity whose primary key is declared by the `pk` tag.
+type TableBehavior interface {
 	TableName() string
+}
+
+// PrimaryKeyBehavior defines the interface that declares the primary key of an Entity.
+type PrimaryKeyBehavior interface {
 	PrimaryKey() *PrimaryKey
+}
+
+// IndexBehavior defines the interface that declares the indexes of an Entity.
+type IndexBehavior interface {
 	Indexes() Indexes
 }
+
+// SchemaBehavior defines the interface that places an Entity in the named schema.
+type SchemaBehavior interface {
+	SchemaName() string
+}
+
+// PreviousTableNameBehavior defines the interface that declares the previous name of the renamed table.
+type PreviousTableNameBehavior interface {
+	PreviousTableName() string
+}
+
+// entity wraps TableBehavior to satisfy EntityBehavior.
+type entity struct {
+	TableBehavior
+}
+
+// Entity wraps the Entity that only implements TableBehavior so that it can be used as EntityBehavior.
+// The primary key and indexes are taken from the struct tags.
+func Entity(tb TableBehavior) EntityBehavior {
+	if eb, ok := tb.(EntityBehavior); ok {
+		return eb
+	}
+
+	return &entity{TableBehavior: tb}
+}
+
+func (e *entity) PrimaryKey() *PrimaryKey {
+	if pb, ok := e.TableBehavior.(PrimaryKeyBehavior); ok {
+		return pb.PrimaryKey()
+	}
+
+	return nil
+}
+
+func (e *entity) Indexes() Indexes {
+	if ib, ok := e.TableBehavior.(IndexBehavior); ok {
+		return ib.Indexes()
+	}
+
+	return nil
+}
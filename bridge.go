@@ -0,0 +1,85 @@
+package spoon
+
+import (
+	"github.com/pi9min/spoon/internal/bridge"
+	"github.com/pkg/errors"
+)
+
+func init() {
+	bridge.ClientParser = func(c any) bridge.Parser {
+		return &bridgeParser{parser: c.(*Client).parser}
+	}
+	bridge.NewParser = func(tagPrefix, ignoreTag string) bridge.Parser {
+		return &bridgeParser{parser: newParser(tagPrefix, ignoreTag, "")}
+	}
+	bridge.NewSchema = func(c any, tables []any) (any, error) {
+		ts := make([]*Table, 0, len(tables))
+		for _, t := range tables {
+			ts = append(ts, t.(*Table))
+		}
+		s, err := newSchema(c.(*Client).param, ts)
+		if err != nil {
+			return nil, err
+		}
+
+		return s, nil
+	}
+}
+
+// bridgeParser is the parser used by the packages that parse the Entity from the source without reflection.
+type bridgeParser struct {
+	*parser
+}
+
+func (p *bridgeParser) TagPrefix() string {
+	return p.tagPrefix
+}
+
+func (p *bridgeParser) ParseField(name, tag string, t *bridge.Type) (any, error) {
+	col, err := p.parseFieldTag(name, tag)
+	if err == errIgnoreField {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	col.staticType, col.staticNull = parseGoType(t, col.size)
+
+	if err := col.validateType(); err != nil {
+		return nil, err
+	}
+
+	return col, nil
+}
+
+// LintField checks the struct tag and the type of the column that are accepted by the parser but have no effect.
+func (p *bridgeParser) LintField(column any, tag string) error {
+	col := column.(*Column)
+	if unknown := p.unknownTags(tag); len(unknown) != 0 {
+		return errors.Errorf("field %s: unknown tag %q", col.name, unknown[0])
+	}
+
+	if col.size != 0 {
+		switch ct := col.staticType; {
+		case ct.base != typeString && ct.base != typeBytes:
+			return errors.Errorf("field %s: size can only be used for STRING or BYTES column, but %s", col.name, GoogleSQL.typeString(ct))
+		case ct.size == 0:
+			return errors.Errorf("field %s: size %d is out of range, so the length is MAX", col.name, col.size)
+		}
+	}
+
+	return nil
+}
+
+func (p *bridgeParser) NewTable(entity any, columns []any) (any, error) {
+	cols := make([]*Column, 0, len(columns))
+	for _, c := range columns {
+		cols = append(cols, c.(*Column))
+	}
+	t, err := p.newTable(declare(entity.(TableBehavior)), cols)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
@@ -14,8 +14,15 @@ const (
 )
 
 type optionParam struct {
-	tagPrefix string
-	ignoreTag string
+	tagPrefix      string
+	ignoreTag      string
+	schemaName     string
+	sequences      Sequences
+	dbName         string
+	dbOptions      DatabaseOptions
+	roles          Roles
+	localityGroups LocalityGroups
+	formatOptions  []FormatOption
 }
 
 // Client is Google Cloud Spanner schema generator
@@ -38,50 +45,65 @@ func New(opts ...Option) (*Client, error) {
 	}
 
 	c := &Client{
-		parser: newParser(op.tagPrefix, op.ignoreTag),
+		param:  op,
+		parser: newParser(op.tagPrefix, op.ignoreTag, op.schemaName),
 	}
 
 	return c, nil
 }
 
+// format returns the format of the client options overridden by opts.
+func (c *Client) format(opts ...FormatOption) *format {
+	return newFormat(append(append([]FormatOption{}, c.param.formatOptions...), opts...))
+}
+
 // GenerateCreateTable outputs the `CREATE TABLE` schema of the specified Entity as a string.
-func (c *Client) GenerateCreateTable(eb EntityBehavior) (string, error) {
+// The interleaved parent that is not qualified is written as declared, because the other tables are not known.
+func (c *Client) GenerateCreateTable(eb TableBehavior, opts ...FormatOption) (string, error) {
 	t, err := c.parser.Parse(eb)
 	if err != nil {
 		return "", err
 	}
+	f := c.format(opts...)
+	if err := t.validateDialect(f.dialect); err != nil {
+		return "", err
+	}
 
-	return t.CreateTableSchema(), nil
+	return t.createTableSchema(f), nil
 }
 
 // GenerateCreateTables outputs the `CREATE TABLE` schema of the specified Entity as a string slices.
-func (c *Client) GenerateCreateTables(ebs []EntityBehavior) ([]string, error) {
+func (c *Client) GenerateCreateTables(ebs []EntityBehavior, opts ...FormatOption) ([]string, error) {
 	tables, err := c.parser.ParseMulti(ebs)
 	if err != nil {
 		return nil, err
 	}
 
+	f := c.format(opts...)
 	ss := make([]string, 0, len(ebs))
 	for i := range tables {
 		t := tables[i]
-		ss = append(ss, t.CreateTableSchema())
+		if err := t.validateDialect(f.dialect); err != nil {
+			return nil, err
+		}
+		ss = append(ss, t.createTableSchema(f))
 	}
 
 	return ss, nil
 }
 
 // GenerateDropTable outputs the `DROP TABLE` schema of the specified Entity as a string.
-func (c *Client) GenerateDropTable(eb EntityBehavior) (string, error) {
+func (c *Client) GenerateDropTable(eb TableBehavior, opts ...FormatOption) (string, error) {
 	t, err := c.parser.Parse(eb)
 	if err != nil {
 		return "", err
 	}
 
-	return t.DropTableSchema(), nil
+	return t.dropTableSchema(c.format(opts...)), nil
 }
 
 // GenerateDropTables outputs the `DROP TABLE` schema of the specified Entity as a string slices.
-func (c *Client) GenerateDropTables(ebs []EntityBehavior) ([]string, error) {
+func (c *Client) GenerateDropTables(ebs []EntityBehavior, opts ...FormatOption) ([]string, error) {
 	tables, err := c.parser.ParseMulti(ebs)
 	if err != nil {
 		return nil, err
@@ -90,14 +112,14 @@ func (c *Client) GenerateDropTables(ebs []EntityBehavior) ([]string, error) {
 	ss := make([]string, 0, len(ebs))
 	for i := range tables {
 		t := tables[i]
-		ss = append(ss, t.DropTableSchema())
+		ss = append(ss, t.dropTableSchema(c.format(opts...)))
 	}
 
 	return ss, nil
 }
 
 // GenerateCreateIndexes outputs the `CREATE INDEX` schema of the specified Entity as a string slices.
-func (c *Client) GenerateCreateIndexes(eb EntityBehavior) ([]string, error) {
+func (c *Client) GenerateCreateIndexes(eb TableBehavior, opts ...FormatOption) ([]string, error) {
 	t, err := c.parser.Parse(eb)
 	if err != nil {
 		return nil, err
@@ -107,14 +129,14 @@ func (c *Client) GenerateCreateIndexes(eb EntityBehavior) ([]string, error) {
 	ss := make([]string, 0, len(indexes))
 	for i := range indexes {
 		idx := indexes[i]
-		ss = append(ss, idx.CreateIndexSchema())
+		ss = append(ss, idx.createIndexSchema(c.format(opts...)))
 	}
 
 	return ss, nil
 }
 
 // GenerateDropIndexes outputs the `DROP INDEX` schema of the specified Entity as a string slices.
-func (c *Client) GenerateDropIndexes(eb EntityBehavior) ([]string, error) {
+func (c *Client) GenerateDropIndexes(eb TableBehavior, opts ...FormatOption) ([]string, error) {
 	t, err := c.parser.Parse(eb)
 	if err != nil {
 		return nil, err
@@ -124,8 +146,213 @@ func (c *Client) GenerateDropIndexes(eb EntityBehavior) ([]string, error) {
 	ss := make([]string, 0, len(indexes))
 	for i := range indexes {
 		idx := indexes[i]
-		ss = append(ss, idx.DropIndexSchema())
+		ss = append(ss, idx.dropIndexSchema(c.format(opts...)))
+	}
+
+	return ss, nil
+}
+
+// GenerateCreateNamedSchemas outputs the `CREATE SCHEMA` schema of the named schemas used by the specified Entity as a string slices.
+func (c *Client) GenerateCreateNamedSchemas(ebs []EntityBehavior, opts ...FormatOption) ([]string, error) {
+	tables, err := c.parser.ParseMulti(ebs)
+	if err != nil {
+		return nil, err
+	}
+
+	nss := namedSchemas(tables)
+	ss := make([]string, 0, len(nss))
+	for i := range nss {
+		ss = append(ss, nss[i].createNamedSchemaSchema(c.format(opts...)))
+	}
+
+	return ss, nil
+}
+
+// GenerateDropNamedSchemas outputs the `DROP SCHEMA` schema of the named schemas used by the specified Entity as a string slices.
+func (c *Client) GenerateDropNamedSchemas(ebs []EntityBehavior, opts ...FormatOption) ([]string, error) {
+	tables, err := c.parser.ParseMulti(ebs)
+	if err != nil {
+		return nil, err
+	}
+
+	nss := namedSchemas(tables)
+	ss := make([]string, 0, len(nss))
+	for i := range nss {
+		ss = append(ss, nss[i].dropNamedSchemaSchema(c.format(opts...)))
+	}
+
+	return ss, nil
+}
+
+// GenerateCreateSequences outputs the `CREATE SEQUENCE` schema of the sequences set to Client as a string slices.
+func (c *Client) GenerateCreateSequences(opts ...FormatOption) []string {
+	ss := make([]string, 0, len(c.param.sequences))
+	for i := range c.param.sequences {
+		seq := c.param.sequences[i]
+		ss = append(ss, seq.createSequenceSchema(c.format(opts...)))
+	}
+
+	return ss
+}
+
+// GenerateDropSequences outputs the `DROP SEQUENCE` schema of the sequences set to Client as a string slices.
+func (c *Client) GenerateDropSequences(opts ...FormatOption) []string {
+	ss := make([]string, 0, len(c.param.sequences))
+	for i := range c.param.sequences {
+		seq := c.param.sequences[i]
+		ss = append(ss, seq.dropSequenceSchema(c.format(opts...)))
+	}
+
+	return ss
+}
+
+// GenerateCreateLocalityGroups outputs the `CREATE LOCALITY GROUP` schema of the locality groups set to Client as a string slices.
+func (c *Client) GenerateCreateLocalityGroups() []string {
+	ss := make([]string, 0, len(c.param.localityGroups))
+	for i := range c.param.localityGroups {
+		lg := c.param.localityGroups[i]
+		ss = append(ss, lg.CreateLocalityGroupSchema())
+	}
+
+	return ss
+}
+
+// GenerateDropLocalityGroups outputs the `DROP LOCALITY GROUP` schema of the locality groups set to Client as a string slices.
+func (c *Client) GenerateDropLocalityGroups() []string {
+	ss := make([]string, 0, len(c.param.localityGroups))
+	for i := range c.param.localityGroups {
+		lg := c.param.localityGroups[i]
+		ss = append(ss, lg.DropLocalityGroupSchema())
+	}
+
+	return ss
+}
+
+// GenerateAlterLocalityGroups outputs the `ALTER TABLE` schema that sets the locality groups of the specified Entity as a string slices.
+func (c *Client) GenerateAlterLocalityGroups(eb TableBehavior) ([]string, error) {
+	t, err := c.parser.Parse(eb)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.AlterLocalityGroupSchemas(), nil
+}
+
+// GenerateCreateRoles outputs the `CREATE ROLE` schema of the roles set to Client as a string slices.
+func (c *Client) GenerateCreateRoles(opts ...FormatOption) []string {
+	ss := make([]string, 0, len(c.param.roles))
+	for i := range c.param.roles {
+		r := c.param.roles[i]
+		ss = append(ss, r.createRoleSchema(c.format(opts...)))
+	}
+
+	return ss
+}
+
+// GenerateDropRoles outputs the `DROP ROLE` schema of the roles set to Client as a string slices.
+func (c *Client) GenerateDropRoles(opts ...FormatOption) []string {
+	ss := make([]string, 0, len(c.param.roles))
+	for i := range c.param.roles {
+		r := c.param.roles[i]
+		ss = append(ss, r.dropRoleSchema(c.format(opts...)))
+	}
+
+	return ss
+}
+
+// GenerateGrants outputs the `GRANT` schema of the specified Entity as a string slices.
+func (c *Client) GenerateGrants(eb TableBehavior, opts ...FormatOption) ([]string, error) {
+	t, err := c.parser.Parse(eb)
+	if err != nil {
+		return nil, err
+	}
+
+	ss := make([]string, 0, len(t.grants))
+	for i := range t.grants {
+		g := t.grants[i]
+		ss = append(ss, g.grantSchema(c.format(opts...)))
+	}
+
+	return ss, nil
+}
+
+// GenerateRevokes outputs the `REVOKE` schema of the specified Entity as a string slices.
+func (c *Client) GenerateRevokes(eb TableBehavior, opts ...FormatOption) ([]string, error) {
+	t, err := c.parser.Parse(eb)
+	if err != nil {
+		return nil, err
+	}
+
+	ss := make([]string, 0, len(t.grants))
+	for i := range t.grants {
+		g := t.grants[i]
+		ss = append(ss, g.revokeSchema(c.format(opts...)))
 	}
 
 	return ss, nil
 }
+
+// GenerateAlterDatabase outputs the `ALTER DATABASE` schema of the database options set to Client.
+// It returns empty string if no option is set.
+func (c *Client) GenerateAlterDatabase() string {
+	return c.param.dbOptions.AlterDatabaseSchema(c.param.dbName)
+}
+
+// GenerateAlterDatabaseDiff outputs the `ALTER DATABASE` schema that changes old options to the options set to Client.
+// It returns empty string if nothing changed.
+func (c *Client) GenerateAlterDatabaseDiff(old DatabaseOptions) string {
+	return c.param.dbOptions.AlterDatabaseDiffSchema(c.param.dbName, old)
+}
+
+// GenerateSchema outputs the whole schema of the specified Entity as a string slices.
+// See Schema.DDL for the order of the output.
+func (c *Client) GenerateSchema(ebs []EntityBehavior, opts ...FormatOption) ([]string, error) {
+	ss, err := c.GenerateStatements(ebs, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ss.SQL(), nil
+}
+
+// GenerateStatements outputs the statements that create the whole schema of the specified Entity.
+func (c *Client) GenerateStatements(ebs []EntityBehavior, opts ...FormatOption) (Statements, error) {
+	s, err := c.BuildSchema(ebs)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	f := c.format(opts...)
+	if err := s.validateDialect(f.dialect); err != nil {
+		return nil, err
+	}
+
+	return s.statements(f), nil
+}
+
+// GenerateDropStatements outputs the statements that drop the whole schema of the specified Entity.
+func (c *Client) GenerateDropStatements(ebs []EntityBehavior, opts ...FormatOption) (Statements, error) {
+	s, err := c.BuildSchema(ebs)
+	if err != nil {
+		return nil, err
+	}
+
+	f := c.format(opts...)
+	if err := s.validateDialect(f.dialect); err != nil {
+		return nil, err
+	}
+
+	return s.dropStatements(f), nil
+}
+
+// GenerateCreatePropertyGraph outputs the `CREATE PROPERTY GRAPH` schema of the specified PropertyGraph as a string.
+func (c *Client) GenerateCreatePropertyGraph(g *PropertyGraph) (string, error) {
+	return g.createPropertyGraphSchema(c.parser)
+}
+
+// GenerateDropPropertyGraph outputs the `DROP PROPERTY GRAPH` schema of the specified PropertyGraph as a string.
+func (c *Client) GenerateDropPropertyGraph(g *PropertyGraph) string {
+	return g.DropPropertyGraphSchema()
+}
@@ -2,6 +2,7 @@ package spoon_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -118,3 +119,141 @@ func TestGenerateCreateTable(t *testing.T) {
 		})
 	}
 }
+
+type Test3 struct {
+	ID   int64 `db:"sequence=Test3Seq"`
+	Name string
+}
+
+func (t3 *Test3) TableName() string {
+	return "Test3"
+}
+
+func (t3 *Test3) Indexes() spoon.Indexes {
+	return spoon.Indexes{
+		spoon.AddIndex("Test3ByName", "Test3", false, spoon.KeyPart{ColumnName: "Name"}),
+	}
+}
+
+func (t3 *Test3) PrimaryKey() *spoon.PrimaryKey {
+	return spoon.AddPrimaryKey(spoon.KeyPart{ColumnName: "ID"})
+}
+
+type Test4 struct {
+	ID string `db:"sequence=Test4Seq"`
+}
+
+func (t4 *Test4) TableName() string {
+	return "Test4"
+}
+
+func (t4 *Test4) Indexes() spoon.Indexes {
+	return spoon.Indexes{}
+}
+
+func (t4 *Test4) PrimaryKey() *spoon.PrimaryKey {
+	return spoon.AddPrimaryKey(spoon.KeyPart{ColumnName: "ID"})
+}
+
+func TestGenerateSchema(t *testing.T) {
+	cli, err := spoon.New(spoon.WithSequences(spoon.AddSequence("Test3Seq")))
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	expect := []string{
+		"CREATE SEQUENCE `Test3Seq` OPTIONS (sequence_kind = 'bit_reversed_positive')",
+		"CREATE TABLE `Test3` (\n    `ID` INT64 NOT NULL DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE `Test3Seq`)),\n    `Name` STRING(MAX) NOT NULL,\n) PRIMARY KEY (`ID`)",
+		"CREATE INDEX `Test3ByName` ON `Test3` (`Name`)",
+	}
+
+	actual, err := cli.GenerateSchema([]spoon.EntityBehavior{&Test3{}})
+	if err != nil {
+		t.Fatalf("error generate schema %#v", err)
+	}
+	if diff := cmp.Diff(expect, actual); diff != "" {
+		t.Errorf("GenerateSchema Diff:\n%s", diff)
+	}
+
+	if _, err := cli.GenerateCreateTable(&Test4{}); err == nil {
+		t.Errorf("expect error sequence on STRING column")
+	}
+
+	dbCli, err := spoon.New(
+		spoon.WithSequences(spoon.AddSequence("Test3Seq")),
+		spoon.WithDatabase("db", spoon.DatabaseOptions{OptimizerVersion: 5}),
+	)
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+	actual, err = dbCli.GenerateSchema([]spoon.EntityBehavior{&Test3{}})
+	if err != nil {
+		t.Fatalf("error generate schema %#v", err)
+	}
+	if diff := cmp.Diff(append([]string{"ALTER DATABASE `db` SET OPTIONS (optimizer_version = 5)"}, expect...), actual); diff != "" {
+		t.Errorf("GenerateSchema with database Diff:\n%s", diff)
+	}
+
+	noSeqCli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+	if _, err := noSeqCli.GenerateSchema([]spoon.EntityBehavior{&Test3{}}); err == nil {
+		t.Errorf("expect error undefined sequence")
+	}
+}
+
+func TestGenerate_Idempotent(t *testing.T) {
+	cli, err := spoon.New(spoon.Idempotent())
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	createTable, err := cli.GenerateCreateTable(&Test1{})
+	if err != nil {
+		t.Fatalf("error generate create table schema %#v", err)
+	}
+	if diff := cmp.Diff("CREATE TABLE IF NOT EXISTS `Test1` (", strings.SplitN(createTable, "\n", 2)[0]); diff != "" {
+		t.Errorf("GenerateCreateTable Diff:\n%s", diff)
+	}
+
+	dropTable, err := cli.GenerateDropTable(&Test1{})
+	if err != nil {
+		t.Fatalf("error generate drop table schema %#v", err)
+	}
+	if diff := cmp.Diff("DROP TABLE IF EXISTS `Test1`", dropTable); diff != "" {
+		t.Errorf("GenerateDropTable Diff:\n%s", diff)
+	}
+
+	createIndexes, err := cli.GenerateCreateIndexes(&Test1{})
+	if err != nil {
+		t.Fatalf("error generate create indexes %#v", err)
+	}
+	if diff := cmp.Diff([]string{"CREATE INDEX IF NOT EXISTS `Test1ByCreatedAtDesc` ON `Test1` (`CreatedAt` DESC)"}, createIndexes); diff != "" {
+		t.Errorf("GenerateCreateIndexes Diff:\n%s", diff)
+	}
+
+	dropIndexes, err := cli.GenerateDropIndexes(&Test1{})
+	if err != nil {
+		t.Fatalf("error generate drop indexes %#v", err)
+	}
+	if diff := cmp.Diff([]string{"DROP INDEX IF EXISTS `Test1ByCreatedAtDesc`"}, dropIndexes); diff != "" {
+		t.Errorf("GenerateDropIndexes Diff:\n%s", diff)
+	}
+
+	plainDropTable, err := cli.GenerateDropTable(&Test1{}, spoon.IfExists(false))
+	if err != nil {
+		t.Fatalf("error generate drop table schema %#v", err)
+	}
+	if diff := cmp.Diff("DROP TABLE `Test1`", plainDropTable); diff != "" {
+		t.Errorf("GenerateDropTable Diff:\n%s", diff)
+	}
+
+	plainCreateIndexes, err := cli.GenerateCreateIndexes(&Test1{}, spoon.IfNotExists(false))
+	if err != nil {
+		t.Fatalf("error generate create indexes %#v", err)
+	}
+	if diff := cmp.Diff([]string{"CREATE INDEX `Test1ByCreatedAtDesc` ON `Test1` (`CreatedAt` DESC)"}, plainCreateIndexes); diff != "" {
+		t.Errorf("GenerateCreateIndexes Diff:\n%s", diff)
+	}
+}
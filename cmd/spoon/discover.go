@@ -0,0 +1,105 @@
+package main
+
+import (
+	"go/types"
+	"sort"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
+)
+
+const spoonPkgPath = "github.com/pi9min/spoon"
+
+// entity is the type that implements spoon.EntityBehavior or spoon.TableBehavior.
+type entity struct {
+	pkgPath  string
+	typeName string
+	// pointer reports whether only the pointer type implements the interface.
+	pointer bool
+	// tableOnly reports whether the type implements only spoon.TableBehavior, so it is wrapped by spoon.Entity.
+	tableOnly bool
+}
+
+// discover returns the entities declared in the packages matched by the patterns, in order of the package path and the type name.
+func discover(dir string, patterns []string) ([]*entity, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedImports | packages.NeedDeps,
+		Dir:  dir,
+	}
+	pkgs, err := packages.Load(cfg, append([]string{spoonPkgPath}, patterns...)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "load packages")
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, errors.New("packages contain errors")
+	}
+
+	eb, err := lookupInterface(pkgs, "EntityBehavior")
+	if err != nil {
+		return nil, err
+	}
+	tb, err := lookupInterface(pkgs, "TableBehavior")
+	if err != nil {
+		return nil, err
+	}
+
+	var entities []*entity
+	for _, pkg := range pkgs {
+		// The package main can not be imported by the driver.
+		if pkg.Name == "main" || pkg.PkgPath == spoonPkgPath {
+			continue
+		}
+
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			tn, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || !tn.Exported() || tn.IsAlias() {
+				continue
+			}
+			named, ok := tn.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() != 0 {
+				continue
+			}
+			if _, ok := named.Underlying().(*types.Struct); !ok {
+				continue
+			}
+
+			ptr := types.NewPointer(named)
+			switch {
+			case types.Implements(named, eb):
+				entities = append(entities, &entity{pkgPath: pkg.PkgPath, typeName: name})
+			case types.Implements(ptr, eb):
+				entities = append(entities, &entity{pkgPath: pkg.PkgPath, typeName: name, pointer: true})
+			case types.Implements(named, tb):
+				entities = append(entities, &entity{pkgPath: pkg.PkgPath, typeName: name, tableOnly: true})
+			case types.Implements(ptr, tb):
+				entities = append(entities, &entity{pkgPath: pkg.PkgPath, typeName: name, pointer: true, tableOnly: true})
+			}
+		}
+	}
+
+	sort.SliceStable(entities, func(i, j int) bool {
+		return entities[i].pkgPath < entities[j].pkgPath
+	})
+
+	return entities, nil
+}
+
+// lookupInterface returns the interface of the spoon package.
+func lookupInterface(pkgs []*packages.Package, name string) (*types.Interface, error) {
+	var obj types.Object
+	for _, pkg := range pkgs {
+		if pkg.PkgPath == spoonPkgPath {
+			obj = pkg.Types.Scope().Lookup(name)
+		}
+	}
+	if obj == nil {
+		return nil, errors.Errorf("%s.%s is not found", spoonPkgPath, name)
+	}
+	iface, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, errors.Errorf("%s.%s is not interface", spoonPkgPath, name)
+	}
+
+	return iface, nil
+}
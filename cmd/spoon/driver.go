@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// driverParam is the parameter of the driver program.
+type driverParam struct {
+	Command   string
+	TagPrefix string
+	IgnoreTag string
+	// File is the DDL file compared by the check and compat commands.
+	File string
+	// Dir and Name are the migration directory and the name of the migration written by the migrate command.
+	Dir      string
+	Name     string
+	Imports  []driverImport
+	Entities []string
+}
+
+type driverImport struct {
+	Name string
+	Path string
+}
+
+var driverTemplate = template.Must(template.New("driver").Parse(`// Code generated by spoon. DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pi9min/spoon"
+{{- range .Imports}}
+	{{.Name}} {{printf "%q" .Path}}
+{{- end}}
+)
+
+func main() {
+	cli, err := spoon.New(spoon.TagPrefix({{printf "%q" .TagPrefix}}), spoon.IgnoreTag({{printf "%q" .IgnoreTag}}))
+	if err != nil {
+		fail(err)
+	}
+
+	s, err := cli.BuildSchema([]spoon.EntityBehavior{
+{{- range .Entities}}
+		{{.}},
+{{- end}}
+	})
+	if err != nil {
+		fail(err)
+	}
+
+	var ss spoon.Statements
+	switch {{printf "%q" .Command}} {
+	case "create":
+		for _, t := range s.Tables() {
+			ss = append(ss, t.CreateTableStatement())
+		}
+	case "drop":
+		// The grants and the indexes are dropped before the tables, as Spanner requires.
+		ss = s.DropStatements()
+	case "indexes":
+		for _, idx := range s.Indexes() {
+			ss = append(ss, idx.CreateIndexStatement())
+		}
+	case "check":
+		ddl, err := os.ReadFile({{printf "%q" .File}})
+		if err != nil {
+			fail(err)
+		}
+		if err := s.Validate(); err != nil {
+			fail(err)
+		}
+		drifts, err := s.CompareDDL(string(ddl))
+		if err != nil {
+			fail(err)
+		}
+		if len(drifts) != 0 {
+			fmt.Printf("--- %s\n+++ entities\n%s", {{printf "%q" .File}}, drifts)
+			fail(fmt.Errorf("schema drifts from %s", {{printf "%q" .File}}))
+		}
+		return
+	case "compat":
+		ddl, err := os.ReadFile({{printf "%q" .File}})
+		if err != nil {
+			fail(err)
+		}
+		old, err := spoon.ParseDDL(string(ddl))
+		if err != nil {
+			fail(err)
+		}
+		changes, err := s.CheckCompatibility(old)
+		if err != nil {
+			fail(err)
+		}
+		fmt.Print(changes)
+		if changes.HasBreaking() {
+			fail(fmt.Errorf("breaking changes from %s", {{printf "%q" .File}}))
+		}
+		return
+	case "migrate":
+		d, err := spoon.OpenMigrationDir({{printf "%q" .Dir}})
+		if err != nil {
+			fail(err)
+		}
+		m, err := d.WriteNext(s, {{printf "%q" .Name}})
+		if err != nil {
+			fail(err)
+		}
+		fmt.Printf("%s\n%s\n", m.UpFile, m.DownFile)
+		if len(m.Irreversibles) != 0 {
+			fmt.Printf("irreversible changes:\n%s", m.Irreversibles)
+		}
+		return
+	}
+
+	if err := spoon.WriteStatements(os.Stdout, ss); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+`))
+
+// newDriverParam returns the parameter of the driver program that outputs the DDL of the entities,
+// or compares it with the DDL file.
+func newDriverParam(command, tagPrefix, ignoreTag, file string, entities []*entity) *driverParam {
+	p := &driverParam{
+		Command:   command,
+		TagPrefix: tagPrefix,
+		IgnoreTag: ignoreTag,
+		File:      file,
+	}
+
+	names := make(map[string]string)
+	for _, e := range entities {
+		name, ok := names[e.pkgPath]
+		if !ok {
+			name = fmt.Sprintf("p%d", len(names))
+			names[e.pkgPath] = name
+			p.Imports = append(p.Imports, driverImport{Name: name, Path: e.pkgPath})
+		}
+
+		expr := fmt.Sprintf("%s.%s{}", name, e.typeName)
+		if e.pointer {
+			expr = "&" + expr
+		}
+		if e.tableOnly {
+			expr = "spoon.Entity(" + expr + ")"
+		}
+		p.Entities = append(p.Entities, expr)
+	}
+
+	return p
+}
+
+// driverSource returns the source of the driver program.
+func driverSource(p *driverParam) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := driverTemplate.Execute(&buf, p); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// runDriver builds the driver program in the temporary directory under dir, so it is built in the module of dir,
+// and returns its output. The output is also returned with the error if the driver program fails.
+func runDriver(dir string, p *driverParam) ([]byte, error) {
+	src, err := driverSource(p)
+	if err != nil {
+		return nil, errors.Wrap(err, "generate driver")
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	tmpDir, err := os.MkdirTemp(absDir, ".spoon-driver-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), src, 0644); err != nil {
+		return nil, err
+	}
+
+	bin := filepath.Join(tmpDir, "driver")
+	build := exec.Command("go", "build", "-o", bin, ".")
+	build.Dir = tmpDir
+	var stderr bytes.Buffer
+	build.Stderr = &stderr
+	if err := build.Run(); err != nil {
+		return nil, errors.Errorf("build driver: %v\n%s", err, stderr.String())
+	}
+
+	stderr.Reset()
+	run := exec.Command(bin)
+	run.Stderr = &stderr
+	out, err := run.Output()
+	if err != nil {
+		return out, errors.Errorf("run driver: %v\n%s", err, stderr.String())
+	}
+
+	return out, nil
+}
@@ -0,0 +1,119 @@
+// Command spoon outputs the DDL of the spoon entities found in Go packages.
+//
+// The types that implement spoon.EntityBehavior or spoon.TableBehavior are discovered in the packages matched by the patterns,
+// and a temporary driver program that loads them is built and run.
+//
+//	spoon create [-tag db] [-ignore -] [-o file] [packages]
+//	spoon drop [-tag db] [-ignore -] [-o file] [packages]
+//	spoon indexes [-tag db] [-ignore -] [-o file] [packages]
+//	spoon check [-tag db] [-ignore -] -f schema.sql [packages]
+//	spoon compat [-tag db] [-ignore -] -f schema.sql [packages]
+//	spoon migrate [-tag db] [-ignore -] -dir migrations -name add_singers [packages]
+//
+// The check command compares the whole schema of the entities with the DDL file semantically,
+// and exits with non-zero status printing the diff if they differ.
+//
+// The compat command classifies the changes from the schema of the DDL file, such as the previous release,
+// and exits with non-zero status if any change breaks the running versions of the application.
+//
+// The migrate command writes the next version of the migration files to the directory,
+// and exits with non-zero status without writing if the schema has not changed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+var commands = map[string]string{
+	"create":  "output the `CREATE TABLE` schema",
+	"drop":    "output the statements that drop the indexes and the tables",
+	"indexes": "output the `CREATE INDEX` schema",
+	"check":   "compare the whole schema with the DDL file",
+	"compat":  "classify the changes from the DDL file by compatibility",
+	"migrate": "write the next migration to the migration directory",
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: spoon <command> [flags] [packages]")
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, c := range []string{"create", "drop", "indexes", "check", "compat", "migrate"} {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", c, commands[c])
+	}
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("spoon: ")
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	command := os.Args[1]
+	if _, ok := commands[command]; !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	var (
+		tagPrefix string
+		ignoreTag string
+		outFile   string
+		ddlFile   string
+		dir       string
+		name      string
+	)
+	fs := flag.NewFlagSet(command, flag.ExitOnError)
+	fs.StringVar(&tagPrefix, "tag", "db", "set the struct tag prefix")
+	fs.StringVar(&ignoreTag, "ignore", "-", "set the tag value that ignores the field")
+	fs.StringVar(&outFile, "o", "", "set the output file path, or stdout if empty")
+	fs.StringVar(&ddlFile, "f", "", "set the DDL file compared by check and compat")
+	fs.StringVar(&dir, "dir", "", "set the migration directory written by migrate")
+	fs.StringVar(&name, "name", "", "set the name of the migration written by migrate")
+	fs.Parse(os.Args[2:])
+	if (command == "check" || command == "compat") && ddlFile == "" {
+		log.Fatalf("-f is required by %s", command)
+	}
+	if command == "migrate" && (dir == "" || name == "") {
+		log.Fatal("-dir and -name are required by migrate")
+	}
+
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	entities, err := discover(".", patterns)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(entities) == 0 {
+		log.Fatal("no entity is found")
+	}
+
+	p := newDriverParam(command, tagPrefix, ignoreTag, ddlFile, entities)
+	p.Dir, p.Name = dir, name
+	out, err := runDriver(".", p)
+	// The other commands than the DDL output print the report even if they fail.
+	ddlOutput := command == "create" || command == "drop" || command == "indexes"
+	if !ddlOutput {
+		os.Stdout.Write(out)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !ddlOutput {
+		return
+	}
+
+	if outFile == "" {
+		os.Stdout.Write(out)
+		return
+	}
+	if err := os.WriteFile(outFile, out, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	examplePkgPath = "github.com/pi9min/spoon/_example"
+	entityPkgPath  = "github.com/pi9min/spoon/cmd/spoon/testdata/entity"
+)
+
+func TestDiscover(t *testing.T) {
+	entities, err := discover("../..", []string{"./_example"})
+	if err != nil {
+		t.Fatalf("error discover: %v", err)
+	}
+
+	expect := []*entity{
+		{pkgPath: examplePkgPath, typeName: "Balance"},
+		{pkgPath: examplePkgPath, typeName: "Bookmark"},
+		{pkgPath: examplePkgPath, typeName: "Entry"},
+		{pkgPath: examplePkgPath, typeName: "NestParent"},
+		{pkgPath: examplePkgPath, typeName: "PlayerComment"},
+		{pkgPath: examplePkgPath, typeName: "User", pointer: true},
+	}
+	if diff := cmp.Diff(expect, entities, cmp.AllowUnexported(entity{})); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+
+	entities, err = discover(".", []string{"./testdata/entity"})
+	if err != nil {
+		t.Fatalf("error discover: %v", err)
+	}
+
+	expect = []*entity{
+		{pkgPath: entityPkgPath, typeName: "Item"},
+		{pkgPath: entityPkgPath, typeName: "Player", pointer: true, tableOnly: true},
+	}
+	if diff := cmp.Diff(expect, entities, cmp.AllowUnexported(entity{})); diff != "" {
+		t.Errorf("Diff of TableBehavior:\n%s", diff)
+	}
+}
+
+func TestDriverSource(t *testing.T) {
+	p := newDriverParam("create", "db", "-", "", []*entity{
+		{pkgPath: examplePkgPath, typeName: "Entry"},
+		{pkgPath: examplePkgPath, typeName: "User", pointer: true},
+		{pkgPath: entityPkgPath, typeName: "Player", pointer: true, tableOnly: true},
+	})
+
+	src, err := driverSource(p)
+	if err != nil {
+		t.Fatalf("error driverSource: %v", err)
+	}
+
+	for _, expect := range []string{
+		`p0 "github.com/pi9min/spoon/_example"`,
+		`p1 "github.com/pi9min/spoon/cmd/spoon/testdata/entity"`,
+		`cli, err := spoon.New(spoon.TagPrefix("db"), spoon.IgnoreTag("-"))`,
+		"\t\tp0.Entry{},\n\t\t&p0.User{},\n\t\tspoon.Entity(&p1.Player{}),\n",
+		`switch "create" {`,
+	} {
+		if !strings.Contains(string(src), expect) {
+			t.Errorf("driver source does not contain %q:\n%s", expect, src)
+		}
+	}
+}
+
+func TestRunDriver(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the driver program")
+	}
+
+	entities, err := discover("../..", []string{"./_example"})
+	if err != nil {
+		t.Fatalf("error discover: %v", err)
+	}
+
+	out, err := runDriver("../..", newDriverParam("indexes", "db", "-", "", entities))
+	if err != nil {
+		t.Fatalf("error runDriver: %v", err)
+	}
+
+	expect := "CREATE UNIQUE INDEX `BalanceByUserIDCurrencyID` ON `Balance` (`UserID`, `CurrencyID`);\n\n" +
+		"CREATE UNIQUE INDEX `BookmarkByUserIDEntryID` ON `Bookmark` (`UserID`, `EntryID` DESC);\n\n" +
+		"CREATE INDEX `EntryByTitle` ON `Entry` (`Title`);\n\n" +
+		"CREATE NULL_FILTERED INDEX `PlayerCommentByPlayerIDCommentNullFiltered` ON `PlayerComment` (`PlayerID`, `Comment`);\n"
+	if diff := cmp.Diff(expect, string(out)); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+
+	entities, err = discover(".", []string{"./testdata/entity"})
+	if err != nil {
+		t.Fatalf("error discover: %v", err)
+	}
+
+	out, err = runDriver(".", newDriverParam("create", "db", "-", "", entities))
+	if err != nil {
+		t.Fatalf("error runDriver: %v", err)
+	}
+
+	expect = "CREATE TABLE `Item` (\n    `ID` INT64 NOT NULL,\n    `Title` STRING(MAX) NOT NULL,\n) PRIMARY KEY (`ID`);\n\n" +
+		"CREATE TABLE `Player` (\n    `ID` INT64 NOT NULL,\n    `Name` STRING(MAX) NOT NULL,\n) PRIMARY KEY (`ID`);\n"
+	if diff := cmp.Diff(expect, string(out)); diff != "" {
+		t.Errorf("Diff of TableBehavior:\n%s", diff)
+	}
+}
+
+func TestRunDriver_Drop(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the driver program")
+	}
+
+	entities, err := discover("../..", []string{"./_example"})
+	if err != nil {
+		t.Fatalf("error discover: %v", err)
+	}
+
+	out, err := runDriver("../..", newDriverParam("drop", "db", "-", "", entities))
+	if err != nil {
+		t.Fatalf("error runDriver: %v", err)
+	}
+
+	// The indexes are dropped before the tables, because Spanner refuses to drop a table that has indexes.
+	expect := "DROP INDEX `PlayerCommentByPlayerIDCommentNullFiltered`;\n\n" +
+		"DROP INDEX `EntryByTitle`;\n\n" +
+		"DROP INDEX `BookmarkByUserIDEntryID`;\n\n" +
+		"DROP INDEX `BalanceByUserIDCurrencyID`;\n\n" +
+		"DROP TABLE `PlayerComment`;\n\n" +
+		"DROP TABLE `NestParent`;\n\n" +
+		"DROP TABLE `Entry`;\n\n" +
+		"DROP TABLE `User`;\n\n" +
+		"DROP TABLE `Bookmark`;\n\n" +
+		"DROP TABLE `Balance`;\n"
+	if diff := cmp.Diff(expect, string(out)); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+}
+
+func TestRunDriver_Check(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the driver program")
+	}
+
+	entities, err := discover("../..", []string{"./_example"})
+	if err != nil {
+		t.Fatalf("error discover: %v", err)
+	}
+
+	if _, err := runDriver("../..", newDriverParam("check", "db", "-", "../../_example/sql/schema.sql", entities)); err != nil {
+		t.Errorf("error check schema.sql: %v", err)
+	}
+
+	schema, err := os.ReadFile("../../_example/sql/schema.sql")
+	if err != nil {
+		t.Fatalf("error read schema.sql: %v", err)
+	}
+	f, err := os.CreateTemp("", "schema-*.sql")
+	if err != nil {
+		t.Fatalf("error create temp file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(strings.Replace(string(schema), "`Title` STRING(MAX)", "`Title` STRING(256)", 1)); err != nil {
+		t.Fatalf("error write temp file: %v", err)
+	}
+	f.Close()
+
+	out, err := runDriver("../..", newDriverParam("check", "db", "-", f.Name(), entities))
+	if err == nil {
+		t.Fatal("expected drift")
+	}
+	expect := "--- " + f.Name() + "\n+++ entities\n" +
+		"TABLE Entry\n" +
+		"- `Title` STRING(256) NOT NULL\n" +
+		"+ `Title` STRING(MAX) NOT NULL\n"
+	if diff := cmp.Diff(expect, string(out)); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+}
+
+func TestRunDriver_Migrate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the driver program")
+	}
+
+	entities, err := discover("../..", []string{"./_example"})
+	if err != nil {
+		t.Fatalf("error discover: %v", err)
+	}
+	dir, err := os.MkdirTemp("", "migrations")
+	if err != nil {
+		t.Fatalf("error create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := newDriverParam("migrate", "db", "-", "", entities)
+	p.Dir, p.Name = dir, "init"
+	out, err := runDriver("../..", p)
+	if err != nil {
+		t.Fatalf("error runDriver: %v", err)
+	}
+	expect := filepath.Join(dir, "000001_init.up.sql") + "\n" + filepath.Join(dir, "000001_init.down.sql") + "\n"
+	if diff := cmp.Diff(expect, string(out)); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+
+	if _, err := runDriver("../..", p); err == nil || !strings.Contains(err.Error(), "no schema change") {
+		t.Errorf("expect no schema change, but %v", err)
+	}
+}
+
+func TestRunDriver_Compat(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the driver program")
+	}
+
+	entities, err := discover("../..", []string{"./_example"})
+	if err != nil {
+		t.Fatalf("error discover: %v", err)
+	}
+
+	schema, err := os.ReadFile("../../_example/sql/schema.sql")
+	if err != nil {
+		t.Fatalf("error read schema.sql: %v", err)
+	}
+	f, err := os.CreateTemp("", "schema-*.sql")
+	if err != nil {
+		t.Fatalf("error create temp file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(strings.Replace(string(schema), "`Title` STRING(MAX)", "`Title` INT64", 1)); err != nil {
+		t.Fatalf("error write temp file: %v", err)
+	}
+	f.Close()
+
+	out, err := runDriver("../..", newDriverParam("compat", "db", "-", f.Name(), entities))
+	if err == nil {
+		t.Fatal("expected breaking change")
+	}
+	expect := "BREAKING COLUMN Entry.Title: type INT64 can not be changed to STRING(MAX) in Spanner\n"
+	if diff := cmp.Diff(expect, string(out)); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "github.com/pi9min/spoon"
+
+type Player struct {
+	ID   int64 `db:"pk"`
+	Name string
+}
+
+func (p *Player) TableName() string {
+	return "Player"
+}
+
+type Item struct {
+	ID    int64
+	Title string
+}
+
+func (i Item) TableName() string {
+	return "Item"
+}
+
+func (i Item) PrimaryKey() *spoon.PrimaryKey {
+	return spoon.AddPrimaryKey(spoon.ColumnName("ID").Asc())
+}
+
+func (i Item) Indexes() spoon.Indexes {
+	return spoon.Indexes{}
+}
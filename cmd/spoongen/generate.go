@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+var columnsTemplate = template.Must(template.New("columns").Parse(`// Code generated by spoongen; DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+	"github.com/pi9min/spoon"
+)
+{{ range .Entities }}
+// {{ .Name }}Columns holds the column names of {{ .Name }}.
+var {{ .Name }}Columns = struct {
+{{- range .Columns }}
+	{{ . }} spoon.ColumnName
+{{- end }}
+}{
+{{- range .Columns }}
+	{{ . }}: "{{ . }}",
+{{- end }}
+}
+{{ end -}}
+`))
+
+type entity struct {
+	Name    string
+	Columns []string
+}
+
+type pkg struct {
+	Package  string
+	Entities []*entity
+}
+
+// generate parses the Go files in dir and returns the source of the column names.
+func generate(dir, tagPrefix, ignoreTag, outFile string) ([]byte, error) {
+	files, err := parseDir(dir, outFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.Errorf("no Go files in %s", dir)
+	}
+
+	structs := make(map[string]*ast.StructType)
+	var typeNames []string
+	tableNames := make(map[string]bool)
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					if st, ok := ts.Type.(*ast.StructType); ok {
+						structs[ts.Name.Name] = st
+						typeNames = append(typeNames, ts.Name.Name)
+					}
+				}
+			case *ast.FuncDecl:
+				if d.Recv == nil || d.Name.Name != "TableName" || len(d.Recv.List) != 1 {
+					continue
+				}
+				if name := receiverName(d.Recv.List[0].Type); name != "" {
+					tableNames[name] = true
+				}
+			}
+		}
+	}
+
+	p := &pkg{Package: files[0].Name.Name}
+	for _, name := range typeNames {
+		if !tableNames[name] {
+			continue
+		}
+
+		cols, err := columns(structs, structs[name], tagPrefix, ignoreTag)
+		if err != nil {
+			return nil, errors.Wrapf(err, "type %s", name)
+		}
+		p.Entities = append(p.Entities, &entity{Name: name, Columns: cols})
+	}
+
+	buf := &bytes.Buffer{}
+	if err := columnsTemplate.Execute(buf, p); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func parseDir(dir, outFile string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == outFile {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(names))
+	for _, name := range names {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	return files, nil
+}
+
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+
+	return ""
+}
+
+// columns returns the column names of the struct in the same order as spoon's parser.
+// The pointer to the struct declared in the package is expanded.
+func columns(structs map[string]*ast.StructType, st *ast.StructType, tagPrefix, ignoreTag string) ([]string, error) {
+	var cols []string
+	for _, field := range st.Fields.List {
+		if star, ok := field.Type.(*ast.StarExpr); ok {
+			if ident, ok := star.X.(*ast.Ident); ok {
+				if nested, ok := structs[ident.Name]; ok {
+					nestedCols, err := columns(structs, nested, tagPrefix, ignoreTag)
+					if err != nil {
+						return nil, err
+					}
+					cols = append(cols, nestedCols...)
+					continue
+				}
+			}
+		}
+
+		if field.Tag != nil {
+			tag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+			if isIgnored(reflect.StructTag(tag).Get(tagPrefix), ignoreTag) {
+				continue
+			}
+		}
+
+		if len(field.Names) == 0 {
+			cols = append(cols, embeddedName(field.Type))
+			continue
+		}
+		for _, n := range field.Names {
+			cols = append(cols, n.Name)
+		}
+	}
+
+	return cols, nil
+}
+
+func isIgnored(tag, ignoreTag string) bool {
+	for _, t := range strings.Split(tag, ",") {
+		if t == ignoreTag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	}
+
+	return ""
+}
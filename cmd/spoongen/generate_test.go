@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testSource = `package model
+
+import "time"
+
+type Base struct {
+	CreatedAt time.Time
+}
+
+type User struct {
+	ID     int64
+	Name   string ` + "`db:\"size=64\"`" + `
+	Memo   string ` + "`db:\"-\"`" + `
+	*Base
+}
+
+func (u *User) TableName() string {
+	return "User"
+}
+
+type NotEntity struct {
+	ID int64
+}
+`
+
+func TestGenerate(t *testing.T) {
+	dir, err := os.MkdirTemp("", "spoongen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.WriteFile(filepath.Join(dir, "model.go"), []byte(testSource), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := `// Code generated by spoongen; DO NOT EDIT.
+
+package model
+
+import (
+	"github.com/pi9min/spoon"
+)
+
+// UserColumns holds the column names of User.
+var UserColumns = struct {
+	ID        spoon.ColumnName
+	Name      spoon.ColumnName
+	CreatedAt spoon.ColumnName
+}{
+	ID:        "ID",
+	Name:      "Name",
+	CreatedAt: "CreatedAt",
+}
+`
+
+	actual, err := generate(dir, "db", "-", "spoon_columns.go")
+	if err != nil {
+		t.Fatalf("error generate %#v", err)
+	}
+	if diff := cmp.Diff(expect, string(actual)); diff != "" {
+		t.Errorf("generate Diff:\n%s", diff)
+	}
+}
@@ -0,0 +1,37 @@
+// Command spoongen generates the typed column names of the spoon entities in a package.
+//
+// The struct types that have the TableName method are treated as entities, and
+// `<Type>Columns` variables holding spoon.ColumnName for each column are generated.
+//
+//	//go:generate spoongen
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	var (
+		dir       string
+		tagPrefix string
+		ignoreTag string
+		outFile   string
+	)
+	flag.StringVar(&dir, "dir", ".", "set the package directory of entities")
+	flag.StringVar(&tagPrefix, "tag", "db", "set the struct tag prefix")
+	flag.StringVar(&ignoreTag, "ignore", "-", "set the tag value that ignores the field")
+	flag.StringVar(&outFile, "o", "spoon_columns.go", "set the output file name in the package directory")
+	flag.Parse()
+
+	src, err := generate(dir, tagPrefix, ignoreTag, outFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, outFile), src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,16 @@
+// Command spoonvet checks the struct tags and the key declarations of the spoon Entity.
+//
+// It can be run by itself or by `go vet`.
+//
+//	spoonvet [-tag db] [-ignore -] [packages]
+//	go vet -vettool=$(which spoonvet) [packages]
+package main
+
+import (
+	"github.com/pi9min/spoon/analysis"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(analysis.Analyzer)
+}
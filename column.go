@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+
+	"github.com/pi9min/spoon/internal/bridge"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -13,112 +16,326 @@ const (
 
 // Column is mapping struct field value.
 type Column struct {
-	name        string
-	isNull      bool
-	size        int
-	reflectType reflect.Type
+	name          string
+	isNull        bool
+	size          int
+	sequenceName  string
+	localityGroup string
+	renamedFrom   string
+	primaryKey    *primaryKeyTag
+	indexes       []*indexTag
+	fieldIndex    []int
+	reflectType   reflect.Type
+	// staticType and staticNull are the type of the column extracted from the source without reflection.
+	staticType columnType
+	staticNull bool
+}
+
+// columnTag holds the values specified by the struct tag.
+type columnTag struct {
+	isNull        bool
+	size          int
+	sequenceName  string
+	localityGroup string
+	renamedFrom   string
+	primaryKey    *primaryKeyTag
+}
+
+// primaryKeyTag holds the key part declared by the `pk` tag.
+// order is 0 when the position is not specified.
+type primaryKeyTag struct {
+	order       int
+	isOrderDesc bool
+	interleave  string
 }
 
-func newColumn(name string, tags map[string]string, rt reflect.Type) *Column {
-	isNull, size, err := parseTags(tags)
+// newTaggedColumn creates Column of the values specified by the struct tag, and the type is set by the caller.
+func newTaggedColumn(name string, tags map[string]string) (*Column, error) {
+	ct, err := parseTags(tags)
 	if err != nil {
-		panic(err)
+		return nil, errors.Wrapf(err, "field %s", name)
 	}
 
 	return &Column{
-		name:        name,
-		isNull:      isNull,
-		size:        size,
-		reflectType: rt,
+		name:          name,
+		isNull:        ct.isNull,
+		size:          ct.size,
+		sequenceName:  ct.sequenceName,
+		localityGroup: ct.localityGroup,
+		renamedFrom:   ct.renamedFrom,
+		primaryKey:    ct.primaryKey,
+	}, nil
+}
+
+func (c *Column) validateType() error {
+	if c.sequenceName != "" {
+		if ct, _ := c.columnType(); ct != (columnType{base: typeInt64}) {
+			return errors.Errorf("field %s: sequence can only be used for INT64 column, but %s", c.name, GoogleSQL.typeString(ct))
+		}
 	}
+
+	return nil
+}
+
+// columnType returns the type of the column, and whether the type is nullable.
+func (c *Column) columnType() (columnType, bool) {
+	if c.reflectType == nil {
+		return c.staticType, c.staticNull
+	}
+
+	return parseType(c.reflectType, c.size)
+}
+
+// Name returns the column name.
+func (c *Column) Name() string {
+	return c.name
+}
+
+// SpannerType returns the Spanner type of the column such as `STRING(MAX)`.
+func (c *Column) SpannerType() string {
+	ct, _ := c.columnType()
+	return GoogleSQL.typeString(ct)
+}
+
+// IsNullable reports whether the column allows NULL.
+func (c *Column) IsNullable() bool {
+	_, tNull := c.columnType()
+	return c.isNull || tNull
+}
+
+// Size returns the size specified by the `size` tag.
+func (c *Column) Size() int {
+	return c.size
+}
+
+// GoType returns the type of the struct field, or nil if the column is extracted from the source.
+func (c *Column) GoType() reflect.Type {
+	return c.reflectType
+}
+
+// SequenceName returns the sequence that generates the default value.
+func (c *Column) SequenceName() string {
+	return c.sequenceName
+}
+
+// LocalityGroup returns the locality group of the column.
+func (c *Column) LocalityGroup() string {
+	return c.localityGroup
+}
+
+// RenamedFrom returns the previous name of the column specified by the `renamed_from` tag.
+func (c *Column) RenamedFrom() string {
+	return c.renamedFrom
 }
 
 // ToSQL is convert struct value to sql.
 // ToSQL convert spanner type from reflect.Type and size
 func (c *Column) ToSQL() string {
-	tStr, tNull := parseTypeToString(c.reflectType, c.size)
+	return c.toSQL(defaultFormat())
+}
+
+func (c *Column) toSQL(f *format) string {
+	ct, tNull := c.columnType()
+	tStr := f.dialect.typeString(ct)
 	// Always NOT NULL if both nulls are not satisfied
 	if !(c.isNull || tNull) {
 		tStr += " NOT NULL"
 	}
-	return fmt.Sprintf("%s %s", Quote(c.name), tStr)
+	if c.sequenceName != "" {
+		switch f.dialect {
+		case PostgreSQL:
+			tStr += " DEFAULT " + nextval(f.quote(c.sequenceName))
+		default:
+			tStr += fmt.Sprintf(" DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE %s))", Quote(c.sequenceName))
+		}
+	}
+	if c.localityGroup != "" {
+		tStr += " " + localityGroupOption(c.localityGroup)
+	}
+	return fmt.Sprintf("%s %s", f.quote(c.name), tStr)
+}
+
+// baseType is the type of the column independent of the dialect.
+type baseType int
+
+const (
+	typeBool baseType = iota + 1
+	typeInt64
+	typeFloat64
+	typeString
+	typeBytes
+	typeDate
+	typeTimestamp
+	typeJSON
+	typeNumeric
+)
+
+// columnType is the type of the column. size is 0 when the length is MAX.
+type columnType struct {
+	base  baseType
+	size  int
+	array bool
 }
 
 func parseTypeToString(t reflect.Type, size int) (string, bool) {
+	ct, isNull := parseType(t, size)
+	return GoogleSQL.typeString(ct), isNull
+}
+
+func parseType(t reflect.Type, size int) (columnType, bool) {
+	return parseGoType(goType(t), size)
+}
+
+// goType describes the reflect type for parseGoType.
+func goType(t reflect.Type) *bridge.Type {
+	gt := &bridge.Type{Kind: t.Kind(), Name: t.Name()}
 	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice:
+		gt.Elem = goType(t.Elem())
+	}
+
+	return gt
+}
+
+// parseGoType returns the type of the column of the Go type, which is described by reflect or go/types.
+func parseGoType(t *bridge.Type, size int) (columnType, bool) {
+	switch t.Kind {
 	// Recursive
 	case reflect.Ptr:
-		return parseTypeToString(t.Elem(), size)
+		return parseGoType(t.Elem, size)
 	case reflect.Bool:
-		return "BOOL", false
+		return columnType{base: typeBool}, false
 	case reflect.Int8, reflect.Uint8, reflect.Int16, reflect.Uint16, reflect.Int, reflect.Uint, reflect.Int32, reflect.Uint32, reflect.Int64, reflect.Uint64:
-		return "INT64", false
+		return columnType{base: typeInt64}, false
 	case reflect.Float32, reflect.Float64:
-		return "FLOAT64", false
+		return columnType{base: typeFloat64}, false
 	case reflect.Slice:
-		switch t.Elem().Kind() {
+		switch t.Elem.Kind {
 		case reflect.Uint8: // []byte
 			if size < 1 || maxByteLength < size {
-				return "BYTES(MAX)", false // MAX=10485760(10MiB)
+				return columnType{base: typeBytes}, false // MAX=10485760(10MiB)
 			}
-			return fmt.Sprintf("BYTES(%d)", size), false
+			return columnType{base: typeBytes, size: size}, false
 		default:
-			typeStr, isNull := parseTypeToString(t.Elem(), size)
-			return array(typeStr), isNull
+			ct, isNull := parseGoType(t.Elem, size)
+			ct.array = true
+			return ct, isNull
 		}
 	}
 
-	switch t.Name() {
+	switch t.Name {
 	case "Time":
-		return "TIMESTAMP", false
+		return columnType{base: typeTimestamp}, false
 	case "NullBool": // https://godoc.org/cloud.google.com/go/spanner#NullBool
-		return "BOOL", true
+		return columnType{base: typeBool}, true
 	case "NullDate", "Date": // https://godoc.org/cloud.google.com/go/spanner#NullDate, https://godoc.org/cloud.google.com/go/civil#Date
-		return "DATE", true
+		return columnType{base: typeDate}, true
 	case "NullFloat64": // https://godoc.org/cloud.google.com/go/spanner#NullFloat64
-		return "FLOAT64", true
+		return columnType{base: typeFloat64}, true
 	case "NullInt64": // https://godoc.org/cloud.google.com/go/spanner#NullInt64
-		return "INT64", true
+		return columnType{base: typeInt64}, true
 	case "NullTime": // https://godoc.org/cloud.google.com/go/spanner#NullTime
-		return "TIMESTAMP", true
+		return columnType{base: typeTimestamp}, true
+	case "NullJSON": // https://godoc.org/cloud.google.com/go/spanner#NullJSON
+		return columnType{base: typeJSON}, true
+	case "NullNumeric": // https://godoc.org/cloud.google.com/go/spanner#NullNumeric
+		return columnType{base: typeNumeric}, true
+	case "Rat": // https://pkg.go.dev/math/big#Rat
+		return columnType{base: typeNumeric}, false
 	}
 
 	// Process the following as a character string.
 	var isNull bool
 	// https://godoc.org/cloud.google.com/go/spanner#NullString
-	if t.Name() == "NullString" {
+	if t.Name == "NullString" {
 		isNull = true
 	}
 
 	if size < 1 || maxStringLength < size {
-		return "STRING(MAX)", isNull // MAX=2621440(2.5mebichars)
+		return columnType{base: typeString}, isNull // MAX=2621440(2.5mebichars)
 	}
 
-	return fmt.Sprintf("STRING(%d)", size), isNull
+	return columnType{base: typeString, size: size}, isNull
 }
 
-func array(s string) string {
-	return "ARRAY<" + s + ">"
+// tagKeys are the keys of the struct tag read by parseTags.
+var tagKeys = map[string]bool{
+	"nullable":       true,
+	"sequence":       true,
+	"locality_group": true,
+	"pk":             true,
+	"desc":           true,
+	"interleave":     true,
+	"size":           true,
+	"renamed_from":   true,
 }
 
-func parseTags(tags map[string]string) (bool, int, error) {
-	var isNull bool
+func parseTags(tags map[string]string) (*columnTag, error) {
+	ct := &columnTag{}
 
 	if _, ok := tags["nullable"]; ok {
-		isNull = true
+		ct.isNull = true
+	}
+
+	// sequence tag
+	if seq, ok := tags["sequence"]; ok {
+		if seq == "" {
+			return nil, errors.New("sequence name is empty")
+		}
+		ct.sequenceName = seq
+	}
+
+	// locality_group tag
+	if lg, ok := tags["locality_group"]; ok {
+		if lg == "" {
+			return nil, errors.New("locality group name is empty")
+		}
+		ct.localityGroup = lg
+	}
+
+	// renamed_from tag
+	if from, ok := tags["renamed_from"]; ok {
+		if from == "" {
+			return nil, errors.New("renamed_from name is empty")
+		}
+		ct.renamedFrom = from
+	}
+
+	// pk, desc and interleave tag
+	if pkStr, ok := tags["pk"]; ok {
+		pkt := &primaryKeyTag{}
+		if pkStr != "" {
+			order, err := strconv.Atoi(pkStr)
+			if err != nil || order < 1 {
+				return nil, errors.Errorf("invalid pk position %s", pkStr)
+			}
+			pkt.order = order
+		}
+		if _, ok := tags["desc"]; ok {
+			pkt.isOrderDesc = true
+		}
+		pkt.interleave = tags["interleave"]
+		ct.primaryKey = pkt
+	} else {
+		if _, ok := tags["desc"]; ok {
+			return nil, errors.New("desc can only be used with pk")
+		}
+		if _, ok := tags["interleave"]; ok {
+			return nil, errors.New("interleave can only be used with pk")
+		}
 	}
 
 	// size tag
 	sizeStr, ok := tags["size"]
 	if !ok {
-		return isNull, 0, nil
+		return ct, nil
 	}
 
 	s, err := strconv.Atoi(sizeStr)
 	if err != nil {
-		return false, 0, err
+		return nil, err
 	}
+	ct.size = s
 
-	return isNull, s, nil
-
+	return ct, nil
 }
@@ -2,6 +2,7 @@ package spoon
 
 import (
 	"encoding/json"
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -77,6 +78,7 @@ func TestColumn_ParseTypeToString(t *testing.T) {
 		{name: "[]spanner.NullString size:0", inputType: []spanner.NullString{}, size: 0, expectTypeStr: "ARRAY<STRING(MAX)>", expectIsNull: true},
 		{name: "[]spanner.NullString size:1", inputType: []spanner.NullString{}, size: 1, expectTypeStr: "ARRAY<STRING(1)>", expectIsNull: true},
 		{name: "[]spanner.NullString size:2621440", inputType: []spanner.NullString{}, size: 2621440, expectTypeStr: "ARRAY<STRING(2621440)>", expectIsNull: true},
+		{name: "big.Rat", inputType: big.Rat{}, size: 0, expectTypeStr: "NUMERIC", expectIsNull: false},
 	}
 
 	for _, tt := range tests {
@@ -92,12 +94,52 @@ func TestColumn_ParseTypeToString(t *testing.T) {
 	}
 }
 
+func TestDialect_TypeString(t *testing.T) {
+	tests := []struct {
+		name             string
+		inputType        interface{}
+		size             int
+		expectGoogleSQL  string
+		expectPostgreSQL string
+	}{
+		{name: "bool", inputType: true, expectGoogleSQL: "BOOL", expectPostgreSQL: "boolean"},
+		{name: "int64", inputType: int64(0), expectGoogleSQL: "INT64", expectPostgreSQL: "bigint"},
+		{name: "float64", inputType: float64(0), expectGoogleSQL: "FLOAT64", expectPostgreSQL: "double precision"},
+		{name: "string size:0", inputType: "", expectGoogleSQL: "STRING(MAX)", expectPostgreSQL: "varchar"},
+		{name: "string size:20", inputType: "", size: 20, expectGoogleSQL: "STRING(20)", expectPostgreSQL: "varchar(20)"},
+		{name: "[]byte size:20", inputType: []byte{}, size: 20, expectGoogleSQL: "BYTES(20)", expectPostgreSQL: "bytea"},
+		{name: "spanner.NullDate", inputType: spanner.NullDate{}, expectGoogleSQL: "DATE", expectPostgreSQL: "date"},
+		{name: "time.Time", inputType: time.Time{}, expectGoogleSQL: "TIMESTAMP", expectPostgreSQL: "timestamptz"},
+		{name: "big.Rat", inputType: big.Rat{}, expectGoogleSQL: "NUMERIC", expectPostgreSQL: "numeric"},
+		{name: "[]string size:10", inputType: []string{}, size: 10, expectGoogleSQL: "ARRAY<STRING(10)>", expectPostgreSQL: "varchar(10)[]"},
+		{name: "[]time.Time", inputType: []time.Time{}, expectGoogleSQL: "ARRAY<TIMESTAMP>", expectPostgreSQL: "timestamptz[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct, _ := parseType(reflect.TypeOf(tt.inputType), tt.size)
+			if diff := cmp.Diff(tt.expectGoogleSQL, GoogleSQL.typeString(ct)); diff != "" {
+				t.Errorf("GoogleSQL Diff:\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.expectPostgreSQL, PostgreSQL.typeString(ct)); diff != "" {
+				t.Errorf("PostgreSQL Diff:\n%s", diff)
+			}
+		})
+	}
+
+	// spanner.NullJSON is not in the supported version of the client library.
+	if diff := cmp.Diff("jsonb", PostgreSQL.typeString(columnType{base: typeJSON})); diff != "" {
+		t.Errorf("PostgreSQL JSON Diff:\n%s", diff)
+	}
+}
+
 func TestColumn_ToSQL(t *testing.T) {
 	type fields struct {
-		name        string
-		isNull      bool
-		size        int
-		reflectType reflect.Type
+		name         string
+		isNull       bool
+		size         int
+		sequenceName string
+		reflectType  reflect.Type
 	}
 	tests := []struct {
 		name   string
@@ -154,14 +196,26 @@ func TestColumn_ToSQL(t *testing.T) {
 			},
 			expect: "`Description` BYTES(MAX)",
 		},
+		{
+			name: "int, not null, sequence",
+			fields: fields{
+				name:         "ID",
+				isNull:       false,
+				size:         0,
+				sequenceName: "UserSeq",
+				reflectType:  reflect.TypeOf(int64(0)),
+			},
+			expect: "`ID` INT64 NOT NULL DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE `UserSeq`))",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Column{
-				name:        tt.fields.name,
-				isNull:      tt.fields.isNull,
-				size:        tt.fields.size,
-				reflectType: tt.fields.reflectType,
+				name:         tt.fields.name,
+				isNull:       tt.fields.isNull,
+				size:         tt.fields.size,
+				sequenceName: tt.fields.sequenceName,
+				reflectType:  tt.fields.reflectType,
 			}
 			if diff := cmp.Diff(tt.expect, c.ToSQL()); diff != "" {
 				t.Errorf("Column.ToSQL() Diff:\n%s", diff)
@@ -0,0 +1,191 @@
+package spoon
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Compatibility is the impact of the schema change on the running versions of the application.
+type Compatibility string
+
+const (
+	// CompatibilitySafe is the change that the running versions can live with.
+	CompatibilitySafe Compatibility = "SAFE"
+	// CompatibilityBackfill is the change that does not break the running versions,
+	// but Spanner backfills or validates the existing data as a long running operation.
+	CompatibilityBackfill Compatibility = "BACKFILL"
+	// CompatibilityBreaking is the change that breaks the running versions, or that Spanner does not allow.
+	CompatibilityBreaking Compatibility = "BREAKING"
+)
+
+// Change is the change of an object between two versions of the schema.
+type Change struct {
+	// Object is the object type and name such as `COLUMN Singers.Name`.
+	Object        string
+	Compatibility Compatibility
+	Message       string
+}
+
+// Changes are alias of change slices.
+type Changes []*Change
+
+// String returns the changes line by line.
+func (cs Changes) String() string {
+	var b strings.Builder
+	for _, c := range cs {
+		fmt.Fprintf(&b, "%s %s: %s\n", c.Compatibility, c.Object, c.Message)
+	}
+
+	return b.String()
+}
+
+// Filter returns the changes of the compatibility.
+func (cs Changes) Filter(compatibility Compatibility) Changes {
+	var filtered Changes
+	for _, c := range cs {
+		if c.Compatibility == compatibility {
+			filtered = append(filtered, c)
+		}
+	}
+
+	return filtered
+}
+
+// HasBreaking reports whether any change is breaking.
+func (cs Changes) HasBreaking() bool {
+	return len(cs.Filter(CompatibilityBreaking)) != 0
+}
+
+// CheckCompatibility compares old schema with s, and classifies each change of the tables, the columns,
+// the indexes and the grants. The objects are matched by name, so a renamed object is a drop and an add
+// unless it is declared by PreviousTableNameBehavior or the `renamed_from` tag.
+// The renamed table is safe because the synonym keeps the previous name, but the renamed column is breaking.
+//
+// The type change follows the rules of Spanner: the length of STRING and BYTES can be changed,
+// and STRING and BYTES can be changed to each other. The other type changes are not allowed.
+// It returns an error if the renames of s can not be applied to old schema.
+func (s *Schema) CheckCompatibility(old *Schema) (Changes, error) {
+	var cs Changes
+	add := func(object string, compatibility Compatibility, format string, args ...interface{}) {
+		cs = append(cs, &Change{Object: object, Compatibility: compatibility, Message: fmt.Sprintf(format, args...)})
+	}
+
+	f := defaultFormat()
+	r := s.renaming(old, f)
+	renamed, err := r.apply(old)
+	if err != nil {
+		return nil, err
+	}
+	old = renamed
+	for _, t := range s.tables {
+		if from, ok := r.previousTableName(t.name); ok {
+			add("TABLE "+t.name, CompatibilitySafe, "table is renamed from %s, and the synonym %s keeps the running versions working", from, from)
+		}
+		for _, c := range t.columns {
+			if from, ok := r.previousColumnName(t.name, c.name); ok {
+				add("COLUMN "+t.name+"."+c.name, CompatibilityBreaking, "column is renamed from %s, so the running versions fail to access it", from)
+			}
+		}
+	}
+
+	for _, t := range s.tables {
+		ot := old.Table(t.name)
+		if ot == nil {
+			add("TABLE "+t.name, CompatibilitySafe, "table is added")
+			continue
+		}
+		if !t.primaryKey.equal(ot.primaryKey) {
+			add("TABLE "+t.name, CompatibilityBreaking, "primary key or interleave is changed, which Spanner does not allow")
+		}
+
+		for _, c := range t.columns {
+			object := "COLUMN " + t.name + "." + c.name
+			oc := ot.Column(c.name)
+			switch {
+			case oc == nil && notNull(c) && c.sequenceName == "":
+				add(object, CompatibilityBreaking, "NOT NULL column without default is added, so the running versions fail to insert")
+				continue
+			case oc == nil:
+				add(object, CompatibilitySafe, "column is added")
+				continue
+			}
+
+			oct, _ := oc.columnType()
+			ct, _ := c.columnType()
+			if oct != ct {
+				compatibility, message := typeChange(oct, ct)
+				add(object, compatibility, "%s", message)
+			}
+			switch {
+			case notNull(c) && !notNull(oc):
+				add(object, CompatibilityBreaking, "column becomes NOT NULL, so the running versions fail to write null")
+			case !notNull(c) && notNull(oc):
+				add(object, CompatibilitySafe, "column becomes nullable")
+			}
+		}
+		for _, oc := range ot.columns {
+			if t.Column(oc.name) == nil {
+				add("COLUMN "+t.name+"."+oc.name, CompatibilityBreaking, "column is dropped")
+			}
+		}
+	}
+	for _, ot := range old.tables {
+		if s.Table(ot.name) == nil {
+			add("TABLE "+ot.name, CompatibilityBreaking, "table is dropped")
+		}
+	}
+
+	for _, idx := range s.Indexes() {
+		object := "INDEX " + idx.name
+		switch oi := old.Index(idx.name); {
+		case oi == nil && old.Table(idx.tableName) == nil:
+			add(object, CompatibilitySafe, "index of the added table is created")
+		case oi == nil && idx.isUnique:
+			add(object, CompatibilityBackfill, "unique index is backfilled, and fails if the existing keys are duplicate")
+		case oi == nil:
+			add(object, CompatibilityBackfill, "index is backfilled")
+		case oi.createIndexSchema(f) != idx.createIndexSchema(f):
+			add(object, CompatibilityBreaking, "index is changed, so it is dropped and backfilled again")
+		}
+	}
+	for _, oi := range old.Indexes() {
+		if s.Index(oi.name) == nil && s.Table(oi.tableName) != nil {
+			add("INDEX "+oi.name, CompatibilityBreaking, "index is dropped, so the queries that force it fail")
+		}
+	}
+
+	grants := make(map[string]bool)
+	for _, t := range s.tables {
+		for _, g := range t.grants {
+			grants[g.grantSchema(f)] = true
+		}
+	}
+	for _, t := range old.tables {
+		for _, g := range t.grants {
+			if !grants[g.grantSchema(f)] && s.Table(t.name) != nil {
+				add("ROLE "+g.roleName, CompatibilityBreaking, "%s ON TABLE %s is revoked", g.privilegeSQL(f), g.tableName)
+			}
+		}
+	}
+
+	return cs, nil
+}
+
+// typeChange classifies the change of the column type by the rules of Spanner.
+func typeChange(old, ct columnType) (Compatibility, string) {
+	from, to := GoogleSQL.typeString(old), GoogleSQL.typeString(ct)
+	stringOrBytes := func(ct columnType) bool {
+		return ct.base == typeString || ct.base == typeBytes
+	}
+
+	switch {
+	case old.array != ct.array || !stringOrBytes(old) || !stringOrBytes(ct):
+		return CompatibilityBreaking, fmt.Sprintf("type %s can not be changed to %s in Spanner", from, to)
+	case old.base != ct.base:
+		return CompatibilityBreaking, fmt.Sprintf("type %s is changed to %s, which Spanner allows, but the running versions read and write the values as the old type", from, to)
+	case ct.size == 0 || old.size != 0 && ct.size > old.size:
+		return CompatibilitySafe, fmt.Sprintf("length of %s is extended to %s", from, to)
+	}
+
+	return CompatibilityBreaking, fmt.Sprintf("length of %s is shortened to %s, so the longer values fail to be written", from, to)
+}
@@ -0,0 +1,89 @@
+package spoon_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+func TestSchema_CheckCompatibility(t *testing.T) {
+	old, err := spoon.ParseDDL(`CREATE SEQUENCE Seq OPTIONS (sequence_kind = 'bit_reversed_positive');
+CREATE TABLE Singers (
+  SingerID INT64 NOT NULL,
+  Name STRING(MAX) NOT NULL,
+  Nickname STRING(64),
+  Bio STRING(MAX),
+  Picture BYTES(MAX),
+  Rank INT64,
+  Score FLOAT64 NOT NULL,
+  Legacy STRING(MAX),
+) PRIMARY KEY (SingerID);
+CREATE TABLE Albums (SingerID INT64 NOT NULL, AlbumID INT64 NOT NULL) PRIMARY KEY (SingerID, AlbumID);
+CREATE TABLE Logs (ID INT64 NOT NULL) PRIMARY KEY (ID);
+CREATE INDEX SingersByName ON Singers (Name);
+CREATE INDEX SingersByRank ON Singers (Rank);
+CREATE ROLE reader;
+GRANT SELECT ON TABLE Singers TO ROLE reader;`)
+	if err != nil {
+		t.Fatalf("error ParseDDL old: %v", err)
+	}
+	s, err := spoon.ParseDDL(`CREATE SEQUENCE Seq OPTIONS (sequence_kind = 'bit_reversed_positive');
+CREATE TABLE Singers (
+  SingerID INT64 NOT NULL,
+  Name STRING(64) NOT NULL,
+  Nickname STRING(MAX),
+  Bio BYTES(MAX),
+  Picture BYTES(MAX),
+  Rank STRING(MAX),
+  Score FLOAT64,
+  Country STRING(MAX),
+  Code INT64 NOT NULL,
+  Serial INT64 NOT NULL DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE Seq)),
+) PRIMARY KEY (SingerID);
+CREATE TABLE Albums (SingerID INT64 NOT NULL, AlbumID INT64 NOT NULL) PRIMARY KEY (AlbumID, SingerID);
+CREATE TABLE Songs (SongID INT64 NOT NULL) PRIMARY KEY (SongID);
+CREATE INDEX SingersByName ON Singers (Name DESC);
+CREATE UNIQUE INDEX SingersByCountry ON Singers (Country);
+CREATE INDEX SongsBySongID ON Songs (SongID);
+CREATE ROLE reader;`)
+	if err != nil {
+		t.Fatalf("error ParseDDL new: %v", err)
+	}
+
+	cs, err := s.CheckCompatibility(old)
+	if err != nil {
+		t.Fatalf("error CheckCompatibility: %v", err)
+	}
+	expect := "BREAKING COLUMN Singers.Name: length of STRING(MAX) is shortened to STRING(64), so the longer values fail to be written\n" +
+		"SAFE COLUMN Singers.Nickname: length of STRING(64) is extended to STRING(MAX)\n" +
+		"BREAKING COLUMN Singers.Bio: type STRING(MAX) is changed to BYTES(MAX), which Spanner allows, but the running versions read and write the values as the old type\n" +
+		"BREAKING COLUMN Singers.Rank: type INT64 can not be changed to STRING(MAX) in Spanner\n" +
+		"SAFE COLUMN Singers.Score: column becomes nullable\n" +
+		"SAFE COLUMN Singers.Country: column is added\n" +
+		"BREAKING COLUMN Singers.Code: NOT NULL column without default is added, so the running versions fail to insert\n" +
+		"SAFE COLUMN Singers.Serial: column is added\n" +
+		"BREAKING COLUMN Singers.Legacy: column is dropped\n" +
+		"BREAKING TABLE Albums: primary key or interleave is changed, which Spanner does not allow\n" +
+		"SAFE TABLE Songs: table is added\n" +
+		"BREAKING TABLE Logs: table is dropped\n" +
+		"BREAKING INDEX SingersByName: index is changed, so it is dropped and backfilled again\n" +
+		"BACKFILL INDEX SingersByCountry: unique index is backfilled, and fails if the existing keys are duplicate\n" +
+		"SAFE INDEX SongsBySongID: index of the added table is created\n" +
+		"BREAKING INDEX SingersByRank: index is dropped, so the queries that force it fail\n" +
+		"BREAKING ROLE reader: SELECT ON TABLE Singers is revoked\n"
+	if diff := cmp.Diff(expect, cs.String()); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+	if !cs.HasBreaking() || len(cs.Filter(spoon.CompatibilityBackfill)) != 1 {
+		t.Errorf("unexpected HasBreaking=%v backfills=%d", cs.HasBreaking(), len(cs.Filter(spoon.CompatibilityBackfill)))
+	}
+
+	cs, err = s.CheckCompatibility(s)
+	if err != nil {
+		t.Fatalf("error CheckCompatibility: %v", err)
+	}
+	if len(cs) != 0 {
+		t.Errorf("expect no change, but %s", cs)
+	}
+}
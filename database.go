@@ -0,0 +1,85 @@
+package spoon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DatabaseOptions holds the options set by `ALTER DATABASE`.
+// Zero value fields are not set.
+type DatabaseOptions struct {
+	VersionRetentionPeriod     string
+	OptimizerVersion           int
+	OptimizerStatisticsPackage string
+	DefaultLeader              string
+}
+
+// databaseOption is pair of option name and the literal value.
+type databaseOption struct {
+	name  string
+	value string
+}
+
+func (o DatabaseOptions) options() []databaseOption {
+	var version string
+	if o.OptimizerVersion != 0 {
+		version = strconv.Itoa(o.OptimizerVersion)
+	}
+
+	return []databaseOption{
+		{name: "version_retention_period", value: quoteOptionString(o.VersionRetentionPeriod)},
+		{name: "optimizer_version", value: version},
+		{name: "optimizer_statistics_package", value: quoteOptionString(o.OptimizerStatisticsPackage)},
+		{name: "default_leader", value: quoteOptionString(o.DefaultLeader)},
+	}
+}
+
+// AlterDatabaseSchema return `ALTER DATABASE` schema.
+// It returns empty string if no option is set.
+func (o DatabaseOptions) AlterDatabaseSchema(dbName string) string {
+	var ss []string
+	for _, opt := range o.options() {
+		if opt.value != "" {
+			ss = append(ss, fmt.Sprintf("%s = %s", opt.name, opt.value))
+		}
+	}
+
+	return alterDatabaseSchema(dbName, ss)
+}
+
+// AlterDatabaseDiffSchema return `ALTER DATABASE` schema that changes old options to o.
+// The options removed from old are reset to null.
+// It returns empty string if nothing changed.
+func (o DatabaseOptions) AlterDatabaseDiffSchema(dbName string, old DatabaseOptions) string {
+	var ss []string
+	oldOpts := old.options()
+	for i, opt := range o.options() {
+		if opt.value == oldOpts[i].value {
+			continue
+		}
+		if opt.value == "" {
+			ss = append(ss, fmt.Sprintf("%s = null", opt.name))
+			continue
+		}
+		ss = append(ss, fmt.Sprintf("%s = %s", opt.name, opt.value))
+	}
+
+	return alterDatabaseSchema(dbName, ss)
+}
+
+func alterDatabaseSchema(dbName string, opts []string) string {
+	if len(opts) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("ALTER DATABASE %s SET OPTIONS (%s)", Quote(dbName), strings.Join(opts, ", "))
+}
+
+func quoteOptionString(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return "'" + s + "'"
+}
@@ -0,0 +1,76 @@
+package spoon_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+func TestDatabaseOptions_AlterDatabaseSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   spoon.DatabaseOptions
+		expect string
+	}{
+		{
+			name:   "1 no option",
+			opts:   spoon.DatabaseOptions{},
+			expect: "",
+		},
+		{
+			name: "2 all options",
+			opts: spoon.DatabaseOptions{
+				VersionRetentionPeriod:     "7d",
+				OptimizerVersion:           5,
+				OptimizerStatisticsPackage: "auto_20191128_14_47_22UTC",
+				DefaultLeader:              "us-central1",
+			},
+			expect: "ALTER DATABASE `db` SET OPTIONS (version_retention_period = '7d', optimizer_version = 5, optimizer_statistics_package = 'auto_20191128_14_47_22UTC', default_leader = 'us-central1')",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expect, tt.opts.AlterDatabaseSchema("db")); diff != "" {
+				t.Errorf("AlterDatabaseSchema Diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDatabaseOptions_AlterDatabaseDiffSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		old    spoon.DatabaseOptions
+		new    spoon.DatabaseOptions
+		expect string
+	}{
+		{
+			name:   "1 no change",
+			old:    spoon.DatabaseOptions{OptimizerVersion: 5},
+			new:    spoon.DatabaseOptions{OptimizerVersion: 5},
+			expect: "",
+		},
+		{
+			name:   "2 change and add",
+			old:    spoon.DatabaseOptions{OptimizerVersion: 4},
+			new:    spoon.DatabaseOptions{OptimizerVersion: 5, DefaultLeader: "us-east1"},
+			expect: "ALTER DATABASE `db` SET OPTIONS (optimizer_version = 5, default_leader = 'us-east1')",
+		},
+		{
+			name:   "3 remove",
+			old:    spoon.DatabaseOptions{VersionRetentionPeriod: "7d", OptimizerVersion: 5},
+			new:    spoon.DatabaseOptions{OptimizerVersion: 5},
+			expect: "ALTER DATABASE `db` SET OPTIONS (version_retention_period = null)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expect, tt.new.AlterDatabaseDiffSchema("db", tt.old)); diff != "" {
+				t.Errorf("AlterDatabaseDiffSchema Diff:\n%s", diff)
+			}
+		})
+	}
+}
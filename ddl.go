@@ -0,0 +1,950 @@
+package spoon
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ParseDDL reads GoogleSQL DDL script such as the output of Schema.Script into Schema.
+// The statements are applied in order, so the script may alter, rename and drop the objects created before.
+//
+// The objects that spoon does not manage, such as views and change streams, are ignored,
+// and so are the clauses of them such as foreign keys, synonyms and the default values other than sequences.
+func ParseDDL(ddl string) (*Schema, error) {
+	st := newDDLState()
+	if err := st.apply(ddl); err != nil {
+		return nil, err
+	}
+
+	return st.schema()
+}
+
+// ddlState is the state of the objects built by applying DDL statements.
+type ddlState struct {
+	dbName         string
+	dbOptions      DatabaseOptions
+	sequences      Sequences
+	roles          Roles
+	localityGroups LocalityGroups
+	tables         []*Table
+}
+
+func newDDLState() *ddlState {
+	return &ddlState{}
+}
+
+// apply applies the statements of DDL script to the state.
+func (st *ddlState) apply(ddl string) error {
+	tokens, err := lexDDL(ddl, GoogleSQL)
+	if err != nil {
+		return err
+	}
+
+	for _, ts := range splitStatements(tokens) {
+		dropped := dropIfExists(ts)
+		p := &ddlParser{tokens: dropped, ifExists: len(dropped) != len(ts)}
+		if err := st.applyStatement(p); err != nil {
+			line := strings.Count(ddl[:ts[0].pos], "\n") + 1
+			return errors.Wrapf(err, "line %d", line)
+		}
+	}
+
+	return nil
+}
+
+// schema returns Schema of the state.
+func (st *ddlState) schema() (*Schema, error) {
+	tables := make([]*Table, 0, len(st.tables))
+	for _, t := range st.tables {
+		t.qualify("")
+		tables = append(tables, t)
+	}
+
+	return newSchema(&optionParam{
+		dbName:         st.dbName,
+		dbOptions:      st.dbOptions,
+		sequences:      st.sequences,
+		roles:          st.roles,
+		localityGroups: st.localityGroups,
+	}, tables)
+}
+
+func (st *ddlState) applyStatement(p *ddlParser) error {
+	switch {
+	case p.accept("CREATE", "TABLE"):
+		return st.createTable(p)
+	case p.accept("ALTER", "TABLE"):
+		return st.alterTable(p)
+	case p.accept("DROP", "TABLE"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		i, err := st.tableIndex(name)
+		if err != nil {
+			if p.ifExists {
+				return nil
+			}
+			return err
+		}
+		st.tables = append(st.tables[:i], st.tables[i+1:]...)
+	case p.accept("RENAME", "TABLE"):
+		r := newRenaming()
+		for {
+			from, err := p.name()
+			if err != nil {
+				return err
+			}
+			if err := p.expect("TO"); err != nil {
+				return err
+			}
+			to, err := p.name()
+			if err != nil {
+				return err
+			}
+			r.tables[from] = to
+			if !p.accept(",") {
+				break
+			}
+		}
+		return st.rename(r)
+	case p.peek("CREATE", "INDEX"), p.peek("CREATE", "UNIQUE"), p.peek("CREATE", "NULL_FILTERED"):
+		return st.createIndex(p)
+	case p.accept("DROP", "INDEX"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		for _, t := range st.tables {
+			for i, idx := range t.indexes {
+				if idx.name == name {
+					t.indexes = append(t.indexes[:i:i], t.indexes[i+1:]...)
+					return nil
+				}
+			}
+		}
+		if p.ifExists {
+			return nil
+		}
+		return errors.Errorf("index %s does not exist", name)
+	case p.accept("CREATE", "SEQUENCE"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		if p.ifExists && st.sequence(name) != nil {
+			return nil
+		}
+		seq := AddSequence(name)
+		if p.accept("OPTIONS") {
+			if err := p.sequenceOptions(seq); err != nil {
+				return err
+			}
+		}
+		st.sequences = append(st.sequences, seq)
+	case p.accept("ALTER", "SEQUENCE"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		seq := st.sequence(name)
+		if seq == nil {
+			return errors.Errorf("sequence %s does not exist", name)
+		}
+		if err := p.expect("SET", "OPTIONS"); err != nil {
+			return err
+		}
+		return p.sequenceOptions(seq)
+	case p.accept("DROP", "SEQUENCE"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		for i, seq := range st.sequences {
+			if seq.name == name {
+				st.sequences = append(st.sequences[:i:i], st.sequences[i+1:]...)
+				return nil
+			}
+		}
+		if p.ifExists {
+			return nil
+		}
+		return errors.Errorf("sequence %s does not exist", name)
+	case p.accept("CREATE", "LOCALITY", "GROUP"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		lg := &LocalityGroup{name: name}
+		if p.accept("OPTIONS") {
+			if err := p.localityGroupOptions(lg); err != nil {
+				return err
+			}
+		}
+		st.localityGroups = append(st.localityGroups, lg)
+	case p.accept("ALTER", "LOCALITY", "GROUP"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		var lg *LocalityGroup
+		for _, g := range st.localityGroups {
+			if g.name == name {
+				lg = g
+			}
+		}
+		if lg == nil {
+			return errors.Errorf("locality group %s does not exist", name)
+		}
+		if err := p.expect("SET", "OPTIONS"); err != nil {
+			return err
+		}
+		return p.localityGroupOptions(lg)
+	case p.accept("DROP", "LOCALITY", "GROUP"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		for i, lg := range st.localityGroups {
+			if lg.name == name {
+				st.localityGroups = append(st.localityGroups[:i:i], st.localityGroups[i+1:]...)
+				return nil
+			}
+		}
+		return errors.Errorf("locality group %s does not exist", name)
+	case p.accept("ALTER", "DATABASE"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		st.dbName = name
+		if err := p.expect("SET", "OPTIONS"); err != nil {
+			return err
+		}
+		return p.databaseOptions(&st.dbOptions)
+	case p.accept("CREATE", "ROLE"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		st.roles = append(st.roles, AddRole(name))
+	case p.accept("DROP", "ROLE"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		for i, r := range st.roles {
+			if r.name == name {
+				st.roles = append(st.roles[:i:i], st.roles[i+1:]...)
+				return nil
+			}
+		}
+		return errors.Errorf("role %s does not exist", name)
+	case p.peek("GRANT"), p.peek("REVOKE"):
+		return st.grant(p)
+	}
+
+	// The named schemas are derived from the tables, and the other objects are not managed.
+	return nil
+}
+
+func (st *ddlState) tableIndex(name string) (int, error) {
+	for i, t := range st.tables {
+		if t.name == name {
+			return i, nil
+		}
+	}
+
+	return 0, errors.Errorf("table %s does not exist", name)
+}
+
+// rename renames the tables and the columns of the state, and the indexes, the grants and the interleaved children follow them.
+func (st *ddlState) rename(r *renaming) error {
+	for from := range r.tables {
+		if _, err := st.tableIndex(from); err != nil {
+			return err
+		}
+	}
+	s, err := st.schema()
+	if err != nil {
+		return err
+	}
+	renamed, err := r.apply(s)
+	if err != nil {
+		return err
+	}
+	st.tables = renamed.tables
+
+	return nil
+}
+
+func (st *ddlState) sequence(name string) *Sequence {
+	for _, seq := range st.sequences {
+		if seq.name == name {
+			return seq
+		}
+	}
+
+	return nil
+}
+
+func (st *ddlState) index(name string) *Index {
+	for _, t := range st.tables {
+		for _, idx := range t.indexes {
+			if idx.name == name {
+				return idx
+			}
+		}
+	}
+
+	return nil
+}
+
+func (st *ddlState) createTable(p *ddlParser) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	t := &Table{name: name}
+
+	err = p.list(func() error {
+		if p.skipConstraint() {
+			return nil
+		}
+		c, err := p.column()
+		if err != nil {
+			return err
+		}
+		t.columns = append(t.columns, c)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := p.expect("PRIMARY", "KEY"); err != nil {
+		return err
+	}
+	kps, err := p.keyParts()
+	if err != nil {
+		return err
+	}
+	t.primaryKey = AddPrimaryKey(kps...)
+
+	for p.accept(",") {
+		switch {
+		case p.accept("INTERLEAVE", "IN", "PARENT"):
+			parent, err := p.name()
+			if err != nil {
+				return err
+			}
+			t.primaryKey.interleavedTableName = parent
+			p.skipUntil(",")
+		case p.accept("OPTIONS"):
+			opts, err := p.options()
+			if err != nil {
+				return err
+			}
+			t.localityGroup = opts["locality_group"]
+		default:
+			// ROW DELETION POLICY
+			p.skipUntil(",")
+		}
+	}
+
+	if _, err := st.tableIndex(name); err == nil && p.ifExists {
+		return p.end()
+	}
+	st.tables = append(st.tables, t)
+
+	return p.end()
+}
+
+func (st *ddlState) alterTable(p *ddlParser) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	i, err := st.tableIndex(name)
+	if err != nil {
+		return err
+	}
+	t := st.tables[i]
+
+	switch {
+	case p.accept("ADD", "COLUMN"):
+		c, err := p.column()
+		if err != nil {
+			return err
+		}
+		if p.ifExists && t.Column(c.name) != nil {
+			return nil
+		}
+		t.columns = append(t.columns, c)
+	case p.accept("DROP", "COLUMN"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		for i, c := range t.columns {
+			if c.name == name {
+				t.columns = append(t.columns[:i:i], t.columns[i+1:]...)
+				return nil
+			}
+		}
+		return errors.Errorf("table %s: column %s does not exist", t.name, name)
+	case p.accept("ALTER", "COLUMN"):
+		return alterColumn(p, t)
+	case p.accept("RENAME", "TO"):
+		// The synonym that follows is not managed.
+		to, err := p.name()
+		if err != nil {
+			return err
+		}
+		r := newRenaming()
+		r.tables[t.name] = to
+		return st.rename(r)
+	case p.accept("RENAME", "COLUMN"):
+		from, err := p.name()
+		if err != nil {
+			return err
+		}
+		if err := p.expect("TO"); err != nil {
+			return err
+		}
+		to, err := p.name()
+		if err != nil {
+			return err
+		}
+		if t.Column(from) == nil {
+			return errors.Errorf("table %s: column %s does not exist", t.name, from)
+		}
+		r := newRenaming()
+		r.columns[t.name] = map[string]string{from: to}
+		return st.rename(r)
+	case p.accept("SET", "OPTIONS"):
+		opts, err := p.options()
+		if err != nil {
+			return err
+		}
+		if lg, ok := opts["locality_group"]; ok {
+			t.localityGroup = lg
+		}
+	case p.accept("SET", "INTERLEAVE", "IN", "PARENT"):
+		parent, err := p.name()
+		if err != nil {
+			return err
+		}
+		pk := *t.primaryKey
+		pk.interleavedTableName = parent
+		t.primaryKey = &pk
+	}
+
+	// The constraints and the row deletion policy are not managed.
+	return nil
+}
+
+func alterColumn(p *ddlParser, t *Table) error {
+	i := p.i
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	c := t.Column(name)
+	if c == nil {
+		return errors.Errorf("table %s: column %s does not exist", t.name, name)
+	}
+
+	switch {
+	case p.accept("SET", "OPTIONS"):
+		opts, err := p.options()
+		if err != nil {
+			return err
+		}
+		if lg, ok := opts["locality_group"]; ok {
+			c.localityGroup = lg
+		}
+		return nil
+	case p.accept("SET", "DEFAULT"):
+		c.sequenceName = p.defaultSequence()
+		return nil
+	case p.accept("DROP", "DEFAULT"):
+		c.sequenceName = ""
+		return nil
+	}
+
+	p.i = i
+	altered, err := p.column()
+	if err != nil {
+		return err
+	}
+	*c = *altered
+
+	return nil
+}
+
+func (st *ddlState) createIndex(p *ddlParser) error {
+	p.accept("CREATE")
+	idx := &Index{}
+	idx.isUnique = p.accept("UNIQUE")
+	idx.nullFiltered = p.accept("NULL_FILTERED")
+	if err := p.expect("INDEX"); err != nil {
+		return err
+	}
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	idx.name = name
+	if err := p.expect("ON"); err != nil {
+		return err
+	}
+	if idx.tableName, err = p.name(); err != nil {
+		return err
+	}
+	if idx.keyParts, err = p.keyParts(); err != nil {
+		return err
+	}
+
+	i, err := st.tableIndex(idx.tableName)
+	if err != nil {
+		return err
+	}
+	if p.ifExists && st.index(name) != nil {
+		return nil
+	}
+	st.tables[i].indexes = append(st.tables[i].indexes, idx)
+
+	// STORING and INTERLEAVE IN are not managed.
+	return nil
+}
+
+// grant applies `GRANT` and `REVOKE` statements of the privileges on the tables.
+func (st *ddlState) grant(p *ddlParser) error {
+	revoke := p.accept("REVOKE")
+	if !revoke {
+		p.accept("GRANT")
+	}
+
+	var privileges []*Grant
+	for {
+		word := p.next()
+		g := &Grant{privilege: Privilege(strings.ToUpper(word.text))}
+		switch g.privilege {
+		case PrivilegeSelect, PrivilegeInsert, PrivilegeUpdate, PrivilegeDelete:
+		default:
+			// The role membership and the privileges on the other objects are not managed.
+			return nil
+		}
+		if p.peek("(") {
+			err := p.list(func() error {
+				c, err := p.name()
+				g.columns = append(g.columns, c)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+		}
+		privileges = append(privileges, g)
+		if !p.accept(",") {
+			break
+		}
+	}
+
+	if !p.accept("ON", "TABLE") {
+		return nil
+	}
+	tables, err := p.names()
+	if err != nil {
+		return err
+	}
+	if !p.accept("TO", "ROLE") && !p.accept("FROM", "ROLE") {
+		return errors.New("role is expected")
+	}
+	roles, err := p.names()
+	if err != nil {
+		return err
+	}
+
+	for _, tableName := range tables {
+		i, err := st.tableIndex(tableName)
+		if err != nil {
+			return err
+		}
+		t := st.tables[i]
+		for _, roleName := range roles {
+			for _, pg := range privileges {
+				g := &Grant{roleName: roleName, privilege: pg.privilege, tableName: tableName, columns: pg.columns}
+				if !revoke {
+					t.grants = append(t.grants, g)
+					continue
+				}
+				for j, tg := range t.grants {
+					if tg.grantSchema(defaultFormat()) == g.grantSchema(defaultFormat()) {
+						t.grants = append(t.grants[:j:j], t.grants[j+1:]...)
+						break
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// ddlParser reads the tokens of a statement.
+type ddlParser struct {
+	tokens []ddlToken
+	i      int
+	// ifExists reports whether the statement has `IF EXISTS` or `IF NOT EXISTS`,
+	// so that dropping a missing object or creating an existing one is skipped.
+	ifExists bool
+}
+
+// peek reports whether the next tokens are the words or the punctuations.
+func (p *ddlParser) peek(words ...string) bool {
+	if p.i+len(words) > len(p.tokens) {
+		return false
+	}
+	for j, w := range words {
+		t := p.tokens[p.i+j]
+		if t.kind != ddlWord && t.kind != ddlPunct || t.norm != w {
+			return false
+		}
+	}
+
+	return true
+}
+
+// accept consumes the next tokens if they are the words or the punctuations.
+func (p *ddlParser) accept(words ...string) bool {
+	if !p.peek(words...) {
+		return false
+	}
+	p.i += len(words)
+
+	return true
+}
+
+func (p *ddlParser) expect(words ...string) error {
+	if !p.accept(words...) {
+		return errors.Errorf("%s is expected, but %s", strings.Join(words, " "), p.current())
+	}
+
+	return nil
+}
+
+func (p *ddlParser) end() error {
+	if p.i < len(p.tokens) {
+		return errors.Errorf("unexpected %s", p.current())
+	}
+
+	return nil
+}
+
+func (p *ddlParser) current() string {
+	if p.i >= len(p.tokens) {
+		return "end of statement"
+	}
+
+	return strconv.Quote(p.tokens[p.i].text)
+}
+
+// next consumes the next token, or returns zero value at the end.
+func (p *ddlParser) next() ddlToken {
+	if p.i >= len(p.tokens) {
+		return ddlToken{}
+	}
+	t := p.tokens[p.i]
+	p.i++
+
+	return t
+}
+
+// name reads the identifier such as `sales.Orders`.
+func (p *ddlParser) name() (string, error) {
+	var parts []string
+	for {
+		// The token is not consumed if it is not a name, so the error reports it.
+		if p.i >= len(p.tokens) || p.tokens[p.i].kind != ddlWord && p.tokens[p.i].kind != ddlIdentifier {
+			return "", errors.Errorf("name is expected, but %s", p.current())
+		}
+		parts = append(parts, p.next().text)
+		if !p.accept(".") {
+			return strings.Join(parts, "."), nil
+		}
+	}
+}
+
+// names reads the comma separated identifiers.
+func (p *ddlParser) names() ([]string, error) {
+	var names []string
+	for {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		if !p.accept(",") {
+			return names, nil
+		}
+	}
+}
+
+// list reads the comma separated items in parentheses, allowing the trailing comma.
+func (p *ddlParser) list(item func() error) error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for !p.accept(")") {
+		if err := item(); err != nil {
+			return err
+		}
+		if !p.accept(",") {
+			return p.expect(")")
+		}
+	}
+
+	return nil
+}
+
+// skipUntil skips the tokens until the punctuation at the same depth of parentheses, or the end.
+func (p *ddlParser) skipUntil(punct string) {
+	depth := 0
+	for ; p.i < len(p.tokens); p.i++ {
+		switch t := p.tokens[p.i]; {
+		case t.kind != ddlPunct:
+		case depth == 0 && (t.norm == punct || t.norm == ")"):
+			return
+		case t.norm == "(":
+			depth++
+		case t.norm == ")":
+			depth--
+		}
+	}
+}
+
+// skipConstraint skips the table constraint such as `FOREIGN KEY` and `CHECK`, and reports whether it is skipped.
+func (p *ddlParser) skipConstraint() bool {
+	if !p.peek("CONSTRAINT") && !p.peek("FOREIGN") && !p.peek("CHECK") && !p.peek("SYNONYM") {
+		return false
+	}
+	p.skipUntil(",")
+
+	return true
+}
+
+// keyParts reads the key parts in parentheses.
+func (p *ddlParser) keyParts() ([]KeyPart, error) {
+	var kps []KeyPart
+	err := p.list(func() error {
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		kp := KeyPart{ColumnName: name}
+		if p.accept("DESC") {
+			kp.IsOrderDesc = true
+		} else {
+			p.accept("ASC")
+		}
+		kps = append(kps, kp)
+		return nil
+	})
+
+	return kps, err
+}
+
+// column reads the column definition.
+func (p *ddlParser) column() (*Column, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	ct, err := p.columnType()
+	if err != nil {
+		return nil, errors.Wrapf(err, "column %s", name)
+	}
+	c := &Column{name: name, isNull: true, size: ct.size, staticType: ct}
+
+	for p.i < len(p.tokens) && !p.peek(",") && !p.peek(")") {
+		switch {
+		case p.accept("NOT", "NULL"):
+			c.isNull = false
+		case p.accept("DEFAULT"):
+			c.sequenceName = p.defaultSequence()
+		case p.accept("OPTIONS"):
+			opts, err := p.options()
+			if err != nil {
+				return nil, err
+			}
+			c.localityGroup = opts["locality_group"]
+		case p.accept("AS"):
+			// The generated column is read as the plain column.
+			p.skipUntil(",")
+		default:
+			p.next()
+		}
+	}
+
+	return c, nil
+}
+
+// defaultSequence reads the default value, and returns the sequence name if it is the next value of the sequence.
+func (p *ddlParser) defaultSequence() string {
+	start := p.i
+	p.skipUntil(",")
+	for i := start; i+2 < p.i; i++ {
+		if p.tokens[i].norm == "GET_NEXT_SEQUENCE_VALUE" && p.tokens[i+2].norm == "SEQUENCE" {
+			q := &ddlParser{tokens: p.tokens[:p.i], i: i + 3}
+			if name, err := q.name(); err == nil {
+				return name
+			}
+		}
+	}
+
+	return ""
+}
+
+// columnType reads the type of the column.
+func (p *ddlParser) columnType() (columnType, error) {
+	if p.accept("ARRAY", "<") {
+		ct, err := p.columnType()
+		if err != nil {
+			return ct, err
+		}
+		ct.array = true
+		return ct, p.expect(">")
+	}
+
+	t := p.next()
+	types := map[string]baseType{
+		"BOOL":      typeBool,
+		"INT64":     typeInt64,
+		"FLOAT64":   typeFloat64,
+		"STRING":    typeString,
+		"BYTES":     typeBytes,
+		"DATE":      typeDate,
+		"TIMESTAMP": typeTimestamp,
+		"JSON":      typeJSON,
+		"NUMERIC":   typeNumeric,
+	}
+	base, ok := types[t.norm]
+	if !ok || t.kind != ddlWord {
+		return columnType{}, errors.Errorf("unsupported type %s", strconv.Quote(t.text))
+	}
+	ct := columnType{base: base}
+	if base != typeString && base != typeBytes {
+		return ct, nil
+	}
+
+	if err := p.expect("("); err != nil {
+		return ct, err
+	}
+	if !p.accept("MAX") {
+		size, err := strconv.Atoi(p.next().text)
+		if err != nil {
+			return ct, errors.Errorf("invalid length of %s", t.text)
+		}
+		ct.size = size
+	}
+
+	return ct, p.expect(")")
+}
+
+// options reads the options in parentheses such as `(locality_group = 'cold')`.
+// The strings are unquoted, and null is read as empty string.
+func (p *ddlParser) options() (map[string]string, error) {
+	opts := make(map[string]string)
+	err := p.list(func() error {
+		name := p.next()
+		if name.kind != ddlWord {
+			return errors.Errorf("option name is expected, but %s", strconv.Quote(name.text))
+		}
+		if err := p.expect("="); err != nil {
+			return err
+		}
+
+		v := p.next()
+		switch {
+		case v.kind == ddlString:
+			s, err := strconv.Unquote(v.text)
+			if err != nil {
+				s = v.text[1 : len(v.text)-1]
+			}
+			opts[strings.ToLower(name.text)] = s
+		case v.norm == "NULL":
+			opts[strings.ToLower(name.text)] = ""
+		case v.norm == "-":
+			opts[strings.ToLower(name.text)] = "-" + p.next().text
+		default:
+			opts[strings.ToLower(name.text)] = v.text
+		}
+		return nil
+	})
+
+	return opts, err
+}
+
+func (p *ddlParser) sequenceOptions(seq *Sequence) error {
+	opts, err := p.options()
+	if err != nil {
+		return err
+	}
+
+	if kind, ok := opts["sequence_kind"]; ok {
+		seq.kind = kind
+	}
+	if min, ok := opts["skip_range_min"]; ok {
+		max := opts["skip_range_max"]
+		seq.hasSkipRange = min != "" && max != ""
+		seq.skipRangeMin, _ = strconv.ParseInt(min, 10, 64)
+		seq.skipRangeMax, _ = strconv.ParseInt(max, 10, 64)
+	}
+	if counter, ok := opts["start_with_counter"]; ok {
+		seq.startWithCounter, _ = strconv.ParseInt(counter, 10, 64)
+	}
+
+	return nil
+}
+
+func (p *ddlParser) localityGroupOptions(lg *LocalityGroup) error {
+	opts, err := p.options()
+	if err != nil {
+		return err
+	}
+
+	if storage, ok := opts["storage"]; ok {
+		lg.storage = Storage(storage)
+	}
+	if spill, ok := opts["ssd_to_hdd_spill_timespan"]; ok {
+		lg.ssdToHddSpillTimespan = spill
+	}
+
+	return nil
+}
+
+func (p *ddlParser) databaseOptions(o *DatabaseOptions) error {
+	opts, err := p.options()
+	if err != nil {
+		return err
+	}
+
+	for name, v := range opts {
+		switch name {
+		case "version_retention_period":
+			o.VersionRetentionPeriod = v
+		case "optimizer_version":
+			o.OptimizerVersion, _ = strconv.Atoi(v)
+		case "optimizer_statistics_package":
+			o.OptimizerStatisticsPackage = v
+		case "default_leader":
+			o.DefaultLeader = v
+		}
+	}
+
+	return nil
+}
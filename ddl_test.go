@@ -0,0 +1,130 @@
+package spoon_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+func TestParseDDL(t *testing.T) {
+	cli, err := spoon.New(
+		spoon.WithDatabase("db", spoon.DatabaseOptions{VersionRetentionPeriod: "7d", OptimizerVersion: 6}),
+		spoon.WithSequences(spoon.AddSequence("Test3Seq", spoon.SkipRange(1, 1000), spoon.StartWithCounter(5))),
+		spoon.WithRoles(spoon.AddRole("analyst"), spoon.AddRole("writer")),
+		spoon.WithLocalityGroups(spoon.AddLocalityGroup("cold", spoon.StorageHDD), spoon.AddLocalityGroupWithSpill("spill", "10d")),
+	)
+	if err != nil {
+		t.Fatalf("error new client: %v", err)
+	}
+	expect, err := cli.BuildSchema([]spoon.EntityBehavior{&Test3{}, &Test5{}, &Test7{}, spoon.Entity(&Test7Parent{}), &Test8{}, &Test2{}, Test1{}})
+	if err != nil {
+		t.Fatalf("error BuildSchema: %v", err)
+	}
+
+	s, err := spoon.ParseDDL(expect.Script())
+	if err != nil {
+		t.Fatalf("error ParseDDL: %v", err)
+	}
+	if diff := cmp.Diff(expect.DDL(), s.DDL()); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+}
+
+func TestParseDDL_Alter(t *testing.T) {
+	s, err := spoon.ParseDDL(`CREATE TABLE Singers (
+  SingerID INT64 NOT NULL,
+  Name STRING(1024),
+  Bio STRING(MAX),
+  CONSTRAINT ck CHECK (SingerID > 0),
+) PRIMARY KEY (SingerID), ROW DELETION POLICY (OLDER_THAN(Name, INTERVAL 1 DAY));
+
+CREATE TABLE Legacy (ID INT64 NOT NULL) PRIMARY KEY (ID);
+CREATE INDEX SingersByName ON Singers (Name) STORING (Bio);
+CREATE VIEW SingerNames SQL SECURITY INVOKER AS SELECT Name FROM Singers;
+
+ALTER TABLE Singers ADD COLUMN Rank INT64 NOT NULL DEFAULT (0);
+ALTER TABLE Singers DROP COLUMN Bio;
+ALTER TABLE Singers ALTER COLUMN Name STRING(MAX) NOT NULL;
+DROP INDEX SingersByName;
+CREATE UNIQUE NULL_FILTERED INDEX SingersByRank ON Singers (Rank DESC);
+DROP TABLE Legacy;
+CREATE ROLE reader;
+GRANT SELECT(Name), SELECT(Rank) ON TABLE Singers TO ROLE reader;
+REVOKE SELECT(Rank) ON TABLE Singers FROM ROLE reader;
+GRANT ROLE reader TO ROLE admin;
+`)
+	if err != nil {
+		t.Fatalf("error ParseDDL: %v", err)
+	}
+
+	expect := []string{
+		"CREATE TABLE `Singers` (\n    `SingerID` INT64 NOT NULL,\n    `Name` STRING(MAX) NOT NULL,\n    `Rank` INT64 NOT NULL,\n) PRIMARY KEY (`SingerID`)",
+		"CREATE UNIQUE NULL_FILTERED INDEX `SingersByRank` ON `Singers` (`Rank` DESC)",
+		"CREATE ROLE `reader`",
+		"GRANT SELECT(`Name`) ON TABLE `Singers` TO ROLE `reader`",
+	}
+	if diff := cmp.Diff(expect, s.DDL()); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+}
+
+func TestParseDDL_IfExists(t *testing.T) {
+	s, err := spoon.ParseDDL(`CREATE TABLE A (ID INT64 NOT NULL) PRIMARY KEY (ID);
+DROP TABLE IF EXISTS B;
+DROP INDEX IF EXISTS AByName;
+DROP SEQUENCE IF EXISTS ASeq;
+CREATE TABLE IF NOT EXISTS A (ID INT64 NOT NULL, Name STRING(MAX)) PRIMARY KEY (ID);
+ALTER TABLE A ADD COLUMN IF NOT EXISTS Name STRING(MAX);
+ALTER TABLE A ADD COLUMN IF NOT EXISTS Name STRING(MAX);
+CREATE INDEX IF NOT EXISTS AByName ON A (Name);
+CREATE INDEX IF NOT EXISTS AByName ON A (Name DESC);
+`)
+	if err != nil {
+		t.Fatalf("error ParseDDL: %v", err)
+	}
+
+	expect := []string{
+		"CREATE TABLE `A` (\n    `ID` INT64 NOT NULL,\n    `Name` STRING(MAX),\n) PRIMARY KEY (`ID`)",
+		"CREATE INDEX `AByName` ON `A` (`Name`)",
+	}
+	if diff := cmp.Diff(expect, s.DDL()); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+}
+
+func TestParseDDL_Error(t *testing.T) {
+	tests := []struct {
+		ddl    string
+		expect string
+	}{
+		{
+			ddl:    "CREATE TABLE T (ID INT32 NOT NULL) PRIMARY KEY (ID)",
+			expect: `line 1: column ID: unsupported type "INT32"`,
+		},
+		{
+			ddl:    "CREATE TABLE T (ID INT64 NOT NULL) PRIMARY KEY (ID);\n\nALTER TABLE U ADD COLUMN Name STRING(MAX)",
+			expect: "line 3: table U does not exist",
+		},
+		{
+			ddl:    "CREATE TABLE T (ID INT64 NOT NULL)",
+			expect: `line 1: PRIMARY KEY is expected, but end of statement`,
+		},
+		{
+			ddl:    "CREATE TABLE",
+			expect: `line 1: name is expected, but end of statement`,
+		},
+		{
+			ddl:    "CREATE TABLE (ID INT64 NOT NULL) PRIMARY KEY (ID)",
+			expect: `line 1: name is expected, but "("`,
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := spoon.ParseDDL(tt.ddl)
+		if err == nil || !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("expect error %q, but %v", tt.expect, err)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package spoon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Dialect is the SQL dialect of Cloud Spanner that DDL statements are written in.
+type Dialect int
+
+const (
+	// GoogleSQL is the default dialect.
+	GoogleSQL Dialect = iota
+	// PostgreSQL is the dialect of the databases that use the PostgreSQL interface.
+	PostgreSQL
+)
+
+// String returns the name of the dialect.
+func (d Dialect) String() string {
+	switch d {
+	case GoogleSQL:
+		return "GoogleSQL"
+	case PostgreSQL:
+		return "PostgreSQL"
+	}
+
+	return fmt.Sprintf("Dialect(%d)", int(d))
+}
+
+// typeMapping defines how the column types are written in the dialect.
+type typeMapping struct {
+	names map[baseType]string
+	// sized is the types that take the length such as `STRING(10)`.
+	sized map[baseType]bool
+	// maxSize is written as the length when the size is not specified, or nothing if empty.
+	maxSize string
+	// unsized is the name used instead when the size is not specified, such as `text` for `varchar`.
+	unsized map[baseType]string
+	array   func(elem string) string
+}
+
+var typeMappings = map[Dialect]*typeMapping{
+	GoogleSQL: {
+		names: map[baseType]string{
+			typeBool:      "BOOL",
+			typeInt64:     "INT64",
+			typeFloat64:   "FLOAT64",
+			typeString:    "STRING",
+			typeBytes:     "BYTES",
+			typeDate:      "DATE",
+			typeTimestamp: "TIMESTAMP",
+			typeJSON:      "JSON",
+			typeNumeric:   "NUMERIC",
+		},
+		sized:   map[baseType]bool{typeString: true, typeBytes: true},
+		maxSize: "MAX",
+		array: func(elem string) string {
+			return "ARRAY<" + elem + ">"
+		},
+	},
+	PostgreSQL: {
+		names: map[baseType]string{
+			typeBool:      "boolean",
+			typeInt64:     "bigint",
+			typeFloat64:   "double precision",
+			typeString:    "varchar",
+			typeBytes:     "bytea",
+			typeDate:      "date",
+			typeTimestamp: "timestamptz",
+			typeJSON:      "jsonb",
+			typeNumeric:   "numeric",
+		},
+		sized: map[baseType]bool{typeString: true},
+		array: func(elem string) string {
+			return elem + "[]"
+		},
+	},
+}
+
+// typeString returns the column type written in the dialect.
+func (d Dialect) typeString(ct columnType) string {
+	return typeMappings[d].typeString(ct)
+}
+
+func (m *typeMapping) typeString(ct columnType) string {
+	s := m.names[ct.base]
+	if name, ok := m.unsized[ct.base]; ok && ct.size == 0 {
+		s = name
+	} else if m.sized[ct.base] {
+		switch {
+		case ct.size != 0:
+			s += fmt.Sprintf("(%d)", ct.size)
+		case m.maxSize != "":
+			s += "(" + m.maxSize + ")"
+		}
+	}
+	if ct.array {
+		s = m.array(s)
+	}
+
+	return s
+}
+
+// quote quotes the identifier in the dialect.
+// The schema-qualified name such as `sales.Orders` is quoted per part.
+func (d Dialect) quote(unquoted string) string {
+	if d == GoogleSQL {
+		return Quote(unquoted)
+	}
+
+	parts := strings.Split(unquoted, ".")
+	for i := range parts {
+		parts[i] = `"` + parts[i] + `"`
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// validate checks that the dialect is known.
+func (d Dialect) validate() error {
+	if _, ok := typeMappings[d]; !ok {
+		return errors.Errorf("unknown dialect %s", d)
+	}
+
+	return nil
+}
+
+// nextval returns the default value of the sequence in PostgreSQL.
+// The name is quoted, because the unquoted name in the string literal is folded to lower case.
+func nextval(quotedSequenceName string) string {
+	return "nextval('" + strings.ReplaceAll(quotedSequenceName, "'", "''") + "')"
+}
+
+// validateDialect checks that the schema uses only the features supported by the dialect.
+func (s *Schema) validateDialect(d Dialect) error {
+	if err := d.validate(); err != nil {
+		return err
+	}
+	if d == GoogleSQL {
+		return nil
+	}
+
+	if s.dbOptions != (DatabaseOptions{}) {
+		return errors.Errorf("database options are not supported in %s dialect", d)
+	}
+	if len(s.localityGroups) != 0 {
+		return errors.Errorf("locality groups are not supported in %s dialect", d)
+	}
+	for _, t := range s.tables {
+		if err := t.validateDialect(d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateDialect checks that the table uses only the features supported by the dialect.
+func (t *Table) validateDialect(d Dialect) error {
+	if err := d.validate(); err != nil {
+		return err
+	}
+	if d == GoogleSQL {
+		return nil
+	}
+
+	if len(t.localityGroups()) != 0 {
+		return errors.Errorf("table %s: locality groups are not supported in %s dialect", t.name, d)
+	}
+	for _, kp := range t.primaryKey.keyParts {
+		if kp.IsOrderDesc {
+			return errors.Errorf("table %s: descending primary key column %s is not supported in %s dialect", t.name, kp.ColumnName, d)
+		}
+	}
+
+	return nil
+}
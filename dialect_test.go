@@ -0,0 +1,161 @@
+package spoon_test
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+type Test9 struct {
+	ID        int64              `db:"pk,sequence=Test9Seq"`
+	Name      string             `db:"size=255"`
+	Tags      []string           `db:"size=16"`
+	Note      spanner.NullString `db:"index"`
+	CreatedAt time.Time
+}
+
+func (t9 *Test9) TableName() string {
+	return "Test9"
+}
+
+type Test9Child struct {
+	ID      int64  `db:"pk=1,interleave=Test9"`
+	ChildID int64  `db:"pk=2"`
+	Payload []byte `db:"nullable"`
+}
+
+func (t *Test9Child) TableName() string {
+	return "Test9Child"
+}
+
+func (t *Test9Child) Indexes() spoon.Indexes {
+	return spoon.Indexes{
+		spoon.AddUniqueIndex("Test9ChildByPayload", "Test9Child", true, spoon.KeyPart{ColumnName: "Payload", IsOrderDesc: true}),
+	}
+}
+
+func TestGenerateSchema_PostgreSQL(t *testing.T) {
+	cli, err := spoon.New(
+		spoon.WithDialect(spoon.PostgreSQL),
+		spoon.WithSequences(spoon.AddSequence("Test9Seq", spoon.SkipRange(1, 1000))),
+		spoon.WithRoles(spoon.AddRole("analyst"), spoon.AddRole("writer")),
+	)
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	actual, err := cli.GenerateSchema([]spoon.EntityBehavior{spoon.Entity(&Test9Child{}), spoon.Entity(&Test9{}), &Test5{}})
+	if err != nil {
+		t.Fatalf("error GenerateSchema: %v", err)
+	}
+
+	expect := []string{
+		`CREATE SEQUENCE "Test9Seq" BIT_REVERSED_POSITIVE SKIP RANGE 1 1000`,
+		"CREATE TABLE \"Test9\" (\n    \"ID\" bigint NOT NULL DEFAULT nextval('\"Test9Seq\"'),\n    \"Name\" varchar(255) NOT NULL,\n    \"Tags\" varchar(16)[] NOT NULL,\n    \"Note\" varchar,\n    \"CreatedAt\" timestamptz NOT NULL,\n    PRIMARY KEY (\"ID\")\n)",
+		"CREATE TABLE \"Test9Child\" (\n    \"ID\" bigint NOT NULL,\n    \"ChildID\" bigint NOT NULL,\n    \"Payload\" bytea,\n    PRIMARY KEY (\"ID\", \"ChildID\")\n) INTERLEAVE IN PARENT \"Test9\"",
+		"CREATE TABLE \"Test5\" (\n    \"ID\" bigint NOT NULL,\n    \"Name\" varchar NOT NULL,\n    \"Amount\" bigint NOT NULL,\n    PRIMARY KEY (\"ID\")\n)",
+		`CREATE INDEX "Test9ByNote" ON "Test9" ("Note")`,
+		`CREATE UNIQUE INDEX "Test9ChildByPayload" ON "Test9Child" ("Payload" DESC) WHERE "Payload" IS NOT NULL`,
+		`CREATE ROLE "analyst"`,
+		`CREATE ROLE "writer"`,
+		`GRANT SELECT ("ID", "Name") ON TABLE "Test5" TO "analyst"`,
+		`GRANT INSERT ON TABLE "Test5" TO "writer"`,
+	}
+	if diff := cmp.Diff(expect, actual); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+}
+
+func TestGenerateDropStatements_PostgreSQL(t *testing.T) {
+	cli, err := spoon.New(spoon.WithDialect(spoon.PostgreSQL), spoon.Idempotent())
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	ss, err := cli.GenerateDropStatements([]spoon.EntityBehavior{&Test7{}, spoon.Entity(&Test7Parent{})})
+	if err != nil {
+		t.Fatalf("error GenerateDropStatements: %v", err)
+	}
+
+	expect := []string{
+		`DROP INDEX IF EXISTS "sales"."OrdersByAmount"`,
+		`DROP TABLE IF EXISTS "sales"."Orders"`,
+		`DROP TABLE IF EXISTS "sales"."Customers"`,
+		`DROP SCHEMA "sales"`,
+	}
+	if diff := cmp.Diff(expect, ss.SQL()); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+}
+
+func TestGenerateSchema_PostgreSQLUnsupported(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []spoon.Option
+		entity []spoon.EntityBehavior
+	}{
+		{
+			name:   "1 descending primary key",
+			entity: []spoon.EntityBehavior{Test1{}, &Test2{}},
+		},
+		{
+			name:   "2 locality group",
+			opts:   []spoon.Option{spoon.WithLocalityGroups(spoon.AddLocalityGroup("cold", spoon.StorageHDD), spoon.AddLocalityGroupWithSpill("spill", "10d"))},
+			entity: []spoon.EntityBehavior{&Test8{}},
+		},
+		{
+			name:   "3 database options",
+			opts:   []spoon.Option{spoon.WithDatabase("db", spoon.DatabaseOptions{OptimizerVersion: 5})},
+			entity: []spoon.EntityBehavior{Test1{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli, err := spoon.New(append(tt.opts, spoon.WithDialect(spoon.PostgreSQL))...)
+			if err != nil {
+				t.Fatalf("error new Client")
+			}
+
+			if _, err := cli.GenerateSchema(tt.entity); err == nil {
+				t.Errorf("expected error, but nil")
+			}
+		})
+	}
+}
+
+func TestOutputDialect_Unknown(t *testing.T) {
+	unknown := spoon.OutputDialect(spoon.Dialect(7))
+	expect := "unknown dialect Dialect(7)"
+
+	if _, err := spoon.New(spoon.WithDialect(spoon.Dialect(7))); err == nil || err.Error() != expect {
+		t.Errorf("New: expected error %q, but %v", expect, err)
+	}
+
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+	ebs := []spoon.EntityBehavior{Test1{}}
+	if err := cli.WriteSchema(io.Discard, ebs, unknown); err == nil || err.Error() != expect {
+		t.Errorf("WriteSchema: expected error %q, but %v", expect, err)
+	}
+	if err := cli.WriteDropSchema(io.Discard, ebs, unknown); err == nil || err.Error() != expect {
+		t.Errorf("WriteDropSchema: expected error %q, but %v", expect, err)
+	}
+	if _, err := cli.CheckSchema(ebs, "", unknown); err == nil || err.Error() != expect {
+		t.Errorf("CheckSchema: expected error %q, but %v", expect, err)
+	}
+
+	s, err := cli.BuildSchema(ebs)
+	if err != nil {
+		t.Fatalf("error BuildSchema: %v", err)
+	}
+	if _, err := s.CompareDDL("", unknown); err == nil || err.Error() != expect {
+		t.Errorf("CompareDDL: expected error %q, but %v", expect, err)
+	}
+}
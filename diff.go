@@ -0,0 +1,359 @@
+package spoon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Diff returns the statements that migrate old schema to s.
+// The objects are matched by name, and the changed indexes are dropped and created again.
+// The table of PreviousTableNameBehavior and the column of the `renamed_from` tag are renamed instead of being dropped,
+// and the renamed table keeps the previous name as the synonym.
+// It returns an error if the primary key of an existing table is changed, because Spanner can not alter it.
+// Only GoogleSQL dialect is supported.
+func (s *Schema) Diff(old *Schema, opts ...FormatOption) (Statements, error) {
+	return s.diff(old, newFormat(opts))
+}
+
+func (s *Schema) diff(old *Schema, f *format) (Statements, error) {
+	if f.dialect != GoogleSQL {
+		return nil, errors.Errorf("diff is not supported in %s dialect", f.dialect)
+	}
+	r := s.renaming(old, f)
+	renamed, err := r.apply(old)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.diffRenamed(renamed, r.ss, f)
+}
+
+// diffRenamed returns the statements that migrate old schema to s, where the renames have been applied to old schema.
+func (s *Schema) diffRenamed(old *Schema, renames Statements, f *format) (Statements, error) {
+	for _, t := range s.tables {
+		if ot := old.Table(t.name); ot != nil && !t.primaryKey.equal(ot.primaryKey) {
+			return nil, errors.Errorf("table %s: primary key can not be changed", t.name)
+		}
+	}
+
+	var ss Statements
+	if s.dbName != "" {
+		if st := alterDatabaseStatement(s.dbName, s.dbOptions.AlterDatabaseDiffSchema(s.dbName, old.dbOptions)); st != nil {
+			ss = append(ss, st)
+		}
+	}
+
+	oldSchemas := make(map[string]bool)
+	for _, ns := range old.NamedSchemas() {
+		oldSchemas[ns.name] = true
+	}
+	for _, ns := range s.NamedSchemas() {
+		if !oldSchemas[ns.name] {
+			ss = append(ss, ns.createNamedSchemaStatement(f))
+		}
+	}
+
+	oldSequences := make(map[string]*Sequence, len(old.sequences))
+	for _, seq := range old.sequences {
+		oldSequences[seq.name] = seq
+	}
+	for _, seq := range s.sequences {
+		switch oseq, ok := oldSequences[seq.name]; {
+		case !ok:
+			ss = append(ss, seq.createSequenceStatement(f))
+		case seq.createSequenceSchema(f) != oseq.createSequenceSchema(f):
+			ss = append(ss, &Statement{Kind: StatementAlter, ObjectType: ObjectSequence, Object: seq.name, SQL: seq.AlterSequenceSchema()})
+		}
+	}
+
+	oldLocalityGroups := make(map[string]*LocalityGroup, len(old.localityGroups))
+	for _, lg := range old.localityGroups {
+		oldLocalityGroups[lg.name] = lg
+	}
+	for _, lg := range s.localityGroups {
+		switch olg, ok := oldLocalityGroups[lg.name]; {
+		case !ok:
+			ss = append(ss, lg.CreateLocalityGroupStatement())
+		case lg.options() != olg.options():
+			ss = append(ss, &Statement{Kind: StatementAlter, ObjectType: ObjectLocalityGroup, Object: lg.name, SQL: lg.AlterLocalityGroupSchema()})
+		}
+	}
+
+	oldRoles := make(map[string]bool, len(old.roles))
+	for _, r := range old.roles {
+		oldRoles[r.name] = true
+	}
+	for _, r := range s.roles {
+		if !oldRoles[r.name] {
+			ss = append(ss, r.createRoleStatement(f))
+		}
+	}
+	ss = append(ss, renames...)
+
+	grants := make(map[string]bool)
+	for _, t := range s.tables {
+		for _, g := range t.grants {
+			grants[g.grantSchema(f)] = true
+		}
+	}
+	oldGrants := make(map[string]bool)
+	for _, t := range old.tables {
+		for _, g := range t.grants {
+			oldGrants[g.grantSchema(f)] = true
+			if !grants[g.grantSchema(f)] {
+				ss = append(ss, g.revokeStatement(f))
+			}
+		}
+	}
+
+	oldIndexes := old.Indexes()
+	for i := len(oldIndexes) - 1; i >= 0; i-- {
+		idx := oldIndexes[i]
+		if ni := s.Index(idx.name); ni == nil || ni.createIndexSchema(f) != idx.createIndexSchema(f) {
+			ss = append(ss, idx.dropIndexStatement(f))
+		}
+	}
+
+	for i := len(old.tables) - 1; i >= 0; i-- {
+		if t := old.tables[i]; s.Table(t.name) == nil {
+			ss = append(ss, t.dropTableStatement(f))
+		}
+	}
+
+	for _, t := range s.tables {
+		ot := old.Table(t.name)
+		if ot == nil {
+			ss = append(ss, t.createTableStatement(f))
+			continue
+		}
+		ss = append(ss, t.alterTableStatements(ot, f)...)
+	}
+
+	for _, idx := range s.Indexes() {
+		if oi := old.Index(idx.name); oi == nil || oi.createIndexSchema(f) != idx.createIndexSchema(f) {
+			ss = append(ss, idx.createIndexStatement(f))
+		}
+	}
+
+	for _, t := range s.tables {
+		for _, g := range t.grants {
+			if !oldGrants[g.grantSchema(f)] {
+				ss = append(ss, g.grantStatement(f))
+			}
+		}
+	}
+
+	roles := make(map[string]bool, len(s.roles))
+	for _, r := range s.roles {
+		roles[r.name] = true
+	}
+	for i := len(old.roles) - 1; i >= 0; i-- {
+		if r := old.roles[i]; !roles[r.name] {
+			ss = append(ss, r.dropRoleStatement(f))
+		}
+	}
+
+	localityGroups := make(map[string]bool, len(s.localityGroups))
+	for _, lg := range s.localityGroups {
+		localityGroups[lg.name] = true
+	}
+	for i := len(old.localityGroups) - 1; i >= 0; i-- {
+		if lg := old.localityGroups[i]; !localityGroups[lg.name] {
+			ss = append(ss, lg.DropLocalityGroupStatement())
+		}
+	}
+
+	sequences := make(map[string]bool, len(s.sequences))
+	for _, seq := range s.sequences {
+		sequences[seq.name] = true
+	}
+	for i := len(old.sequences) - 1; i >= 0; i-- {
+		if seq := old.sequences[i]; !sequences[seq.name] {
+			ss = append(ss, seq.dropSequenceStatement(f))
+		}
+	}
+
+	schemas := make(map[string]bool)
+	for _, ns := range s.NamedSchemas() {
+		schemas[ns.name] = true
+	}
+	oldNss := old.NamedSchemas()
+	for i := len(oldNss) - 1; i >= 0; i-- {
+		if ns := oldNss[i]; !schemas[ns.name] {
+			ss = append(ss, ns.dropNamedSchemaStatement(f))
+		}
+	}
+
+	return ss, nil
+}
+
+// alterTableStatements returns `ALTER TABLE` statements that migrate old table to t.
+// The columns are added and altered in order of t, and the dropped columns come last.
+func (t *Table) alterTableStatements(old *Table, f *format) Statements {
+	var ss Statements
+	alter := func(sql string, destructive bool) *Statement {
+		st := &Statement{
+			Kind:        StatementAlter,
+			ObjectType:  ObjectTable,
+			Object:      t.name,
+			Table:       t.name,
+			Destructive: destructive,
+			SQL:         fmt.Sprintf("ALTER TABLE %s %s", f.quote(t.name), sql),
+		}
+		ss = append(ss, st)
+		return st
+	}
+
+	for _, c := range t.columns {
+		oc := old.Column(c.name)
+		if oc == nil {
+			alter("ADD COLUMN "+c.toSQL(f), false)
+			continue
+		}
+
+		switch {
+		case c.definition(f) != oc.definition(f):
+			alter("ALTER COLUMN "+c.withoutLocalityGroup().toSQL(f), false).Backfill = c.validates(oc)
+		case c.sequenceName != oc.sequenceName && c.sequenceName == "":
+			alter(fmt.Sprintf("ALTER COLUMN %s DROP DEFAULT", f.quote(c.name)), false)
+		case c.sequenceName != oc.sequenceName:
+			alter(fmt.Sprintf("ALTER COLUMN %s SET DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE %s))", f.quote(c.name), Quote(c.sequenceName)), false)
+		}
+		if c.localityGroup != oc.localityGroup {
+			alter(fmt.Sprintf("ALTER COLUMN %s SET %s", f.quote(c.name), localityGroupOptionOrNull(c.localityGroup)), false)
+		}
+	}
+
+	for _, oc := range old.columns {
+		if t.Column(oc.name) == nil {
+			alter("DROP COLUMN "+f.quote(oc.name), true)
+		}
+	}
+
+	if t.localityGroup != old.localityGroup {
+		alter("SET "+localityGroupOptionOrNull(t.localityGroup), false)
+	}
+
+	return ss
+}
+
+// definition returns the type and the nullability of the column without the default value and the options.
+func (c *Column) definition(f *format) string {
+	cc := c.withoutLocalityGroup()
+	cc.sequenceName = ""
+
+	return cc.toSQL(f)
+}
+
+// validates reports whether Spanner validates the existing values when old column is altered to c,
+// that is, the column becomes NOT NULL, its length is shortened, or its type is changed between STRING and BYTES.
+func (c *Column) validates(old *Column) bool {
+	oct, _ := old.columnType()
+	ct, _ := c.columnType()
+
+	return notNull(c) && !notNull(old) || isNarrowed(old, c) || oct.base != ct.base
+}
+
+// withoutLocalityGroup returns the copy of the column without the locality group,
+// because `ALTER COLUMN` sets the options in a separate statement.
+func (c *Column) withoutLocalityGroup() *Column {
+	cc := *c
+	cc.localityGroup = ""
+
+	return &cc
+}
+
+// localityGroupOptionOrNull returns the option that places the object in the locality group, or resets it if name is empty.
+func localityGroupOptionOrNull(name string) string {
+	if name == "" {
+		return "OPTIONS (locality_group = null)"
+	}
+
+	return localityGroupOption(name)
+}
+
+// Irreversible is the change of the up migration whose lost data the down migration can not restore.
+type Irreversible struct {
+	// Object is the object type and name such as `COLUMN Singers.Bio`.
+	Object string
+	Reason string
+}
+
+// Irreversibles are alias of irreversible slices.
+type Irreversibles []*Irreversible
+
+// String returns the irreversible changes line by line.
+func (is Irreversibles) String() string {
+	var b strings.Builder
+	for _, i := range is {
+		b.WriteString(i.Object + ": " + i.Reason + "\n")
+	}
+
+	return b.String()
+}
+
+// ReverseDiff returns the statements that migrate s back to old schema, which is the down migration of Diff,
+// and the irreversible changes such as the dropped columns. The down migration creates them again,
+// but their data is lost, so the changes are reported instead of being reverted silently.
+func (s *Schema) ReverseDiff(old *Schema, opts ...FormatOption) (Statements, Irreversibles, error) {
+	return s.reverseDiff(old, newFormat(opts))
+}
+
+func (s *Schema) reverseDiff(old *Schema, f *format) (Statements, Irreversibles, error) {
+	if f.dialect != GoogleSQL {
+		return nil, nil, errors.Errorf("diff is not supported in %s dialect", f.dialect)
+	}
+	r := s.renaming(old, f)
+	renamed, err := r.apply(old)
+	if err != nil {
+		return nil, nil, err
+	}
+	inv := r.inverse()
+	current, err := inv.apply(s)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The database options changed by the up migration are reset even if old schema does not know the database.
+	reverted := *old
+	if reverted.dbName == "" {
+		reverted.dbName = s.dbName
+	}
+	ss, err := reverted.diffRenamed(current, inv.ss, f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var is Irreversibles
+	for _, ot := range renamed.tables {
+		t := s.Table(ot.name)
+		if t == nil {
+			is = append(is, &Irreversible{Object: "TABLE " + ot.name, Reason: "the rows of the dropped table are not restored"})
+			continue
+		}
+		for _, oc := range ot.columns {
+			if t.Column(oc.name) == nil {
+				is = append(is, &Irreversible{Object: "COLUMN " + ot.name + "." + oc.name, Reason: "the values of the dropped column are not restored"})
+			}
+		}
+	}
+	for _, oseq := range old.sequences {
+		if !s.hasSequence(oseq.name) {
+			is = append(is, &Irreversible{Object: "SEQUENCE " + oseq.name, Reason: "the counter of the dropped sequence is not restored"})
+		}
+	}
+
+	return ss, is, nil
+}
+
+func (s *Schema) hasSequence(name string) bool {
+	for _, seq := range s.sequences {
+		if seq.name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,182 @@
+package spoon_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+func TestSchema_Diff(t *testing.T) {
+	tests := []struct {
+		name   string
+		old    string
+		new    string
+		expect []string
+	}{
+		{
+			name:   "no change",
+			old:    "CREATE TABLE Singers (SingerID INT64 NOT NULL, Name STRING(MAX)) PRIMARY KEY (SingerID)",
+			new:    "CREATE TABLE `Singers` (\n  `SingerID` INT64 NOT NULL,\n  `Name` STRING(MAX),\n) PRIMARY KEY (`SingerID`);",
+			expect: []string{},
+		},
+		{
+			name: "columns and indexes",
+			old: `CREATE TABLE Singers (SingerID INT64 NOT NULL, Name STRING(256), Bio STRING(MAX)) PRIMARY KEY (SingerID);
+CREATE INDEX SingersByName ON Singers (Name);
+CREATE INDEX SingersByBio ON Singers (Bio);`,
+			new: `CREATE SEQUENCE SingerSeq OPTIONS (sequence_kind = 'bit_reversed_positive');
+CREATE TABLE Singers (
+  SingerID INT64 NOT NULL DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE SingerSeq)),
+  Name STRING(MAX) NOT NULL,
+  Rank INT64,
+) PRIMARY KEY (SingerID);
+CREATE INDEX SingersByName ON Singers (Name DESC);`,
+			expect: []string{
+				"CREATE SEQUENCE `SingerSeq` OPTIONS (sequence_kind = 'bit_reversed_positive')",
+				"DROP INDEX `SingersByBio`",
+				"DROP INDEX `SingersByName`",
+				"ALTER TABLE `Singers` ALTER COLUMN `SingerID` SET DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE `SingerSeq`))",
+				"ALTER TABLE `Singers` ALTER COLUMN `Name` STRING(MAX) NOT NULL",
+				"ALTER TABLE `Singers` ADD COLUMN `Rank` INT64",
+				"ALTER TABLE `Singers` DROP COLUMN `Bio`",
+				"CREATE INDEX `SingersByName` ON `Singers` (`Name` DESC)",
+			},
+		},
+		{
+			name: "tables, roles and other objects",
+			old: `ALTER DATABASE db SET OPTIONS (version_retention_period = '7d');
+CREATE SEQUENCE OldSeq OPTIONS (sequence_kind = 'bit_reversed_positive');
+CREATE LOCALITY GROUP cold OPTIONS (storage = 'ssd');
+CREATE TABLE Singers (SingerID INT64 NOT NULL) PRIMARY KEY (SingerID);
+CREATE TABLE Albums (SingerID INT64 NOT NULL, AlbumID INT64 NOT NULL) PRIMARY KEY (SingerID, AlbumID), INTERLEAVE IN PARENT Singers;
+CREATE TABLE sales.Orders (OrderID INT64 NOT NULL) PRIMARY KEY (OrderID);
+CREATE ROLE reader;
+GRANT SELECT ON TABLE Singers TO ROLE reader;`,
+			new: `ALTER DATABASE db SET OPTIONS (optimizer_version = 6);
+CREATE LOCALITY GROUP cold OPTIONS (storage = 'hdd');
+CREATE TABLE Singers (SingerID INT64 NOT NULL) PRIMARY KEY (SingerID), OPTIONS (locality_group = 'cold');
+CREATE TABLE Songs (SongID INT64 NOT NULL) PRIMARY KEY (SongID);
+CREATE ROLE writer;
+GRANT INSERT ON TABLE Songs TO ROLE writer;`,
+			expect: []string{
+				"ALTER DATABASE `db` SET OPTIONS (version_retention_period = null, optimizer_version = 6)",
+				"ALTER LOCALITY GROUP `cold` SET OPTIONS (storage = 'hdd')",
+				"CREATE ROLE `writer`",
+				"REVOKE SELECT ON TABLE `Singers` FROM ROLE `reader`",
+				"DROP TABLE `sales`.`Orders`",
+				"DROP TABLE `Albums`",
+				"ALTER TABLE `Singers` SET OPTIONS (locality_group = 'cold')",
+				"CREATE TABLE `Songs` (\n    `SongID` INT64 NOT NULL,\n) PRIMARY KEY (`SongID`)",
+				"GRANT INSERT ON TABLE `Songs` TO ROLE `writer`",
+				"DROP ROLE `reader`",
+				"DROP SEQUENCE `OldSeq`",
+				"DROP SCHEMA `sales`",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old, err := spoon.ParseDDL(tt.old)
+			if err != nil {
+				t.Fatalf("error ParseDDL old: %v", err)
+			}
+			s, err := spoon.ParseDDL(tt.new)
+			if err != nil {
+				t.Fatalf("error ParseDDL new: %v", err)
+			}
+
+			ss, err := s.Diff(old)
+			if err != nil {
+				t.Fatalf("error Diff: %v", err)
+			}
+			if diff := cmp.Diff(tt.expect, ss.SQL()); diff != "" {
+				t.Errorf("Diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSchema_Diff_Error(t *testing.T) {
+	old, err := spoon.ParseDDL("CREATE TABLE Singers (SingerID INT64 NOT NULL, Name STRING(MAX)) PRIMARY KEY (SingerID)")
+	if err != nil {
+		t.Fatalf("error ParseDDL old: %v", err)
+	}
+	s, err := spoon.ParseDDL("CREATE TABLE Singers (SingerID INT64 NOT NULL, Name STRING(MAX)) PRIMARY KEY (SingerID, Name)")
+	if err != nil {
+		t.Fatalf("error ParseDDL new: %v", err)
+	}
+
+	if _, err := s.Diff(old); err == nil || err.Error() != "table Singers: primary key can not be changed" {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, err := s.Diff(s, spoon.OutputDialect(spoon.PostgreSQL)); err == nil {
+		t.Error("expect error in PostgreSQL dialect")
+	}
+}
+
+func TestSchema_Diff_Backfill(t *testing.T) {
+	old, err := spoon.ParseDDL("CREATE TABLE Singers (SingerID INT64 NOT NULL, Name STRING(64), Bio STRING(MAX) NOT NULL, Data BYTES(MAX), Note STRING(8) NOT NULL) PRIMARY KEY (SingerID)")
+	if err != nil {
+		t.Fatalf("error ParseDDL old: %v", err)
+	}
+	s, err := spoon.ParseDDL("CREATE TABLE Singers (SingerID INT64 NOT NULL, Name STRING(MAX) NOT NULL, Bio STRING(16), Data STRING(MAX), Note STRING(MAX)) PRIMARY KEY (SingerID)")
+	if err != nil {
+		t.Fatalf("error ParseDDL new: %v", err)
+	}
+
+	ss, err := s.Diff(old)
+	if err != nil {
+		t.Fatalf("error Diff: %v", err)
+	}
+	var backfills []bool
+	for _, st := range ss {
+		backfills = append(backfills, st.Backfill)
+	}
+	// NOT NULL, the shortened length and the type change are validated, but the extended length and nullable are not.
+	if diff := cmp.Diff([]bool{true, true, true, false}, backfills); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+}
+
+func TestSchema_ReverseDiff(t *testing.T) {
+	old, err := spoon.ParseDDL(`CREATE SEQUENCE SingerSeq OPTIONS (sequence_kind = 'bit_reversed_positive');
+CREATE TABLE Singers (SingerID INT64 NOT NULL, Name STRING(256), Bio STRING(MAX)) PRIMARY KEY (SingerID);
+CREATE TABLE Legacy (ID INT64 NOT NULL) PRIMARY KEY (ID);`)
+	if err != nil {
+		t.Fatalf("error ParseDDL old: %v", err)
+	}
+	s, err := spoon.ParseDDL(`ALTER DATABASE db SET OPTIONS (optimizer_version = 6);
+CREATE TABLE Singers (SingerID INT64 NOT NULL, Name STRING(MAX) NOT NULL, Rank INT64) PRIMARY KEY (SingerID);
+CREATE INDEX SingersByName ON Singers (Name);`)
+	if err != nil {
+		t.Fatalf("error ParseDDL new: %v", err)
+	}
+
+	ss, is, err := s.ReverseDiff(old)
+	if err != nil {
+		t.Fatalf("error ReverseDiff: %v", err)
+	}
+	expect := []string{
+		"ALTER DATABASE `db` SET OPTIONS (optimizer_version = null)",
+		"CREATE SEQUENCE `SingerSeq` OPTIONS (sequence_kind = 'bit_reversed_positive')",
+		"DROP INDEX `SingersByName`",
+		"ALTER TABLE `Singers` ALTER COLUMN `Name` STRING(256)",
+		"ALTER TABLE `Singers` ADD COLUMN `Bio` STRING(MAX)",
+		"ALTER TABLE `Singers` DROP COLUMN `Rank`",
+		"CREATE TABLE `Legacy` (\n    `ID` INT64 NOT NULL,\n) PRIMARY KEY (`ID`)",
+	}
+	if diff := cmp.Diff(expect, ss.SQL()); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+
+	expectIrreversibles := spoon.Irreversibles{
+		{Object: "COLUMN Singers.Bio", Reason: "the values of the dropped column are not restored"},
+		{Object: "TABLE Legacy", Reason: "the rows of the dropped table are not restored"},
+		{Object: "SEQUENCE SingerSeq", Reason: "the counter of the dropped sequence is not restored"},
+	}
+	if diff := cmp.Diff(expectIrreversibles, is); diff != "" {
+		t.Errorf("Irreversibles Diff:\n%s", diff)
+	}
+}
@@ -0,0 +1,474 @@
+package spoon
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Drift is the difference of an object between the schema and the DDL.
+type Drift struct {
+	// Object is the object type and name such as `TABLE Singer`.
+	Object string
+	// Missing is the definitions of the schema that the DDL lacks,
+	// such as the columns of the table, or the whole statement if the DDL lacks the object.
+	Missing []string
+	// Extra is the definitions of the DDL that the schema lacks.
+	Extra []string
+}
+
+// Drifts are alias of drift slices.
+type Drifts []*Drift
+
+// String returns the readable diff. The lines of the DDL are prefixed with `-` and the lines of the schema with `+`.
+func (ds Drifts) String() string {
+	var b strings.Builder
+	for i, d := range ds {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(d.Object + "\n")
+		for _, def := range d.Extra {
+			writePrefixedLines(&b, "- ", def)
+		}
+		for _, def := range d.Missing {
+			writePrefixedLines(&b, "+ ", def)
+		}
+	}
+
+	return b.String()
+}
+
+func writePrefixedLines(b *strings.Builder, prefix, s string) {
+	for _, line := range strings.Split(s, "\n") {
+		b.WriteString(prefix + line + "\n")
+	}
+}
+
+// CheckSchema compares the whole schema of the specified Entity with the DDL script.
+// See Schema.CompareDDL for how they are compared.
+func (c *Client) CheckSchema(ebs []EntityBehavior, ddl string, opts ...FormatOption) (Drifts, error) {
+	s, err := c.BuildSchema(ebs)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	f := c.format(opts...)
+	if err := s.validateDialect(f.dialect); err != nil {
+		return nil, err
+	}
+
+	return s.compareDDL(ddl, f)
+}
+
+// CompareDDL compares the whole schema with the DDL script semantically, and returns the drifts in order of Statements,
+// followed by the objects that only exist in the DDL. It returns no drift if they are the same.
+//
+// The formatting, the comments, the order of the statements, the order of the columns and the options in `CREATE TABLE`,
+// the case of the keywords and the quotes of the identifiers are ignored, and so are `IF NOT EXISTS` and `IF EXISTS`.
+// The identifiers are compared case-insensitively in GoogleSQL, as Spanner does.
+func (s *Schema) CompareDDL(ddl string, opts ...FormatOption) (Drifts, error) {
+	return s.compareDDL(ddl, newFormat(opts))
+}
+
+func (s *Schema) compareDDL(ddl string, f *format) (Drifts, error) {
+	if err := f.dialect.validate(); err != nil {
+		return nil, err
+	}
+	var expected []*ddlStatement
+	for _, st := range s.statements(f) {
+		sts, err := splitDDL(st.SQL, f.dialect)
+		if err != nil {
+			return nil, errors.Wrapf(err, "schema %s", st.Object)
+		}
+		expected = append(expected, sts...)
+	}
+	actual, err := splitDDL(ddl, f.dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	actualByKey := make(map[string]*ddlStatement, len(actual))
+	for _, st := range actual {
+		if _, ok := actualByKey[st.key]; ok {
+			return nil, errors.Errorf("duplicate %s in DDL", st.object)
+		}
+		actualByKey[st.key] = st
+	}
+
+	var ds Drifts
+	matched := make(map[string]bool, len(expected))
+	for _, e := range expected {
+		a, ok := actualByKey[e.key]
+		if !ok {
+			ds = append(ds, &Drift{Object: e.object, Missing: []string{e.src}})
+			continue
+		}
+		matched[e.key] = true
+
+		if d := diffDefinitions(e, a); d != nil {
+			ds = append(ds, d)
+		}
+	}
+	for _, a := range actual {
+		if !matched[a.key] {
+			ds = append(ds, &Drift{Object: a.object, Extra: []string{a.src}})
+		}
+	}
+
+	return ds, nil
+}
+
+// diffDefinitions returns the drift of the definitions of the same object, or nil.
+func diffDefinitions(expected, actual *ddlStatement) *Drift {
+	count := make(map[string]int)
+	for _, def := range actual.defs {
+		count[def.norm]++
+	}
+	d := &Drift{Object: expected.object}
+	for _, def := range expected.defs {
+		if count[def.norm] > 0 {
+			count[def.norm]--
+			continue
+		}
+		d.Missing = append(d.Missing, def.src)
+	}
+
+	count = make(map[string]int)
+	for _, def := range expected.defs {
+		count[def.norm]++
+	}
+	for _, def := range actual.defs {
+		if count[def.norm] > 0 {
+			count[def.norm]--
+			continue
+		}
+		d.Extra = append(d.Extra, def.src)
+	}
+
+	if len(d.Missing) == 0 && len(d.Extra) == 0 {
+		return nil
+	}
+
+	return d
+}
+
+type ddlTokenKind int
+
+const (
+	ddlWord ddlTokenKind = iota + 1
+	ddlIdentifier
+	ddlString
+	ddlPunct
+)
+
+// ddlToken is the token of DDL. text is the unquoted identifier or the source of the other tokens,
+// norm is the text compared, and pos and end are the offsets in the script.
+type ddlToken struct {
+	kind ddlTokenKind
+	text string
+	norm string
+	pos  int
+	end  int
+}
+
+// ddlDefinition is the part of the statement compared regardless of the order, such as the column of `CREATE TABLE`.
+type ddlDefinition struct {
+	src  string
+	norm string
+}
+
+// ddlStatement is DDL statement split into the definitions.
+type ddlStatement struct {
+	src string
+	// key identifies the object of the statement, or is the whole statement if it has no object.
+	key    string
+	object string
+	defs   []ddlDefinition
+}
+
+// splitDDL splits the DDL script into the statements.
+func splitDDL(ddl string, d Dialect) ([]*ddlStatement, error) {
+	tokens, err := lexDDL(ddl, d)
+	if err != nil {
+		return nil, err
+	}
+
+	var sts []*ddlStatement
+	for _, ts := range splitStatements(tokens) {
+		st, err := newDDLStatement(ddl, ts)
+		if err != nil {
+			return nil, err
+		}
+		sts = append(sts, st)
+	}
+
+	return sts, nil
+}
+
+// splitStatements splits the tokens at the semicolons, dropping the empty statements.
+func splitStatements(tokens []ddlToken) [][]ddlToken {
+	var (
+		sts   [][]ddlToken
+		start int
+	)
+	for i := 0; i <= len(tokens); i++ {
+		if i < len(tokens) && tokens[i].norm != ";" {
+			continue
+		}
+		if start < i {
+			sts = append(sts, tokens[start:i])
+		}
+		start = i + 1
+	}
+
+	return sts
+}
+
+func newDDLStatement(ddl string, tokens []ddlToken) (*ddlStatement, error) {
+	src := ddl[tokens[0].pos:tokens[len(tokens)-1].end]
+	tokens = dropIfExists(tokens)
+	if len(tokens) == 0 {
+		return nil, errors.Errorf("empty statement %q", src)
+	}
+	st := &ddlStatement{src: ddl[tokens[0].pos:tokens[len(tokens)-1].end]}
+
+	words := make([]string, 0, 5)
+	for _, t := range tokens {
+		if t.kind != ddlWord || len(words) == cap(words) {
+			break
+		}
+		words = append(words, strings.ToUpper(t.text))
+	}
+	st.object = strings.Join(words, " ")
+
+	// The object type follows the modifiers such as `UNIQUE` and `NULL_FILTERED`.
+	i, n := 1, 1
+	switch {
+	case len(words) > 1 && words[0] == "CREATE":
+		for i < len(words) && (words[i] == "UNIQUE" || words[i] == "NULL_FILTERED" || words[i] == "OR" || words[i] == "REPLACE") {
+			i++
+		}
+		if i < len(words) && (words[i] == "LOCALITY" || words[i] == "PROPERTY" || words[i] == "CHANGE" || words[i] == "SEARCH") {
+			n = 2
+		}
+	case len(words) > 1 && words[0] == "ALTER" && words[1] == "DATABASE":
+	default:
+		i = len(words)
+	}
+	if i+n > len(words) {
+		st.key = normDDL(tokens)
+		st.defs = []ddlDefinition{{src: st.src, norm: st.key}}
+		return st, nil
+	}
+	objectType := strings.Join(words[i:i+n], " ")
+
+	name, next := ddlName(tokens, i+n)
+	st.object = objectType + " " + name
+	st.key = objectType + " " + normDDL(tokens[i+n:next])
+
+	switch {
+	case objectType == "TABLE" && next < len(tokens) && tokens[next].norm == "(":
+		// The columns and the clauses such as `PRIMARY KEY` and `INTERLEAVE IN PARENT` are compared regardless of the order.
+		columns, close := ddlList(ddl, tokens, next)
+		st.defs = append(columns, ddlClauses(ddl, tokens[close:])...)
+	case words[0] == "ALTER" && next+2 < len(tokens) && strings.EqualFold(tokens[next+1].text, "OPTIONS") && tokens[next+2].norm == "(":
+		st.defs, _ = ddlList(ddl, tokens, next+2)
+	default:
+		st.defs = []ddlDefinition{{src: st.src, norm: normDDL(tokens)}}
+	}
+
+	return st, nil
+}
+
+// dropIfExists drops `IF NOT EXISTS` and `IF EXISTS` from the tokens.
+func dropIfExists(tokens []ddlToken) []ddlToken {
+	dropped := make([]ddlToken, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].kind == ddlWord && strings.EqualFold(tokens[i].text, "IF") {
+			switch {
+			case i+2 < len(tokens) && strings.EqualFold(tokens[i+1].text, "NOT") && strings.EqualFold(tokens[i+2].text, "EXISTS"):
+				i += 2
+				continue
+			case i+1 < len(tokens) && strings.EqualFold(tokens[i+1].text, "EXISTS"):
+				i++
+				continue
+			}
+		}
+		dropped = append(dropped, tokens[i])
+	}
+
+	return dropped
+}
+
+// ddlName returns the name of the object that starts at tokens[i] such as `sales.Orders`, and the index after it.
+func ddlName(tokens []ddlToken, i int) (string, int) {
+	var parts []string
+	for i < len(tokens) && (tokens[i].kind == ddlWord || tokens[i].kind == ddlIdentifier) {
+		parts = append(parts, tokens[i].text)
+		i++
+		if i >= len(tokens) || tokens[i].norm != "." {
+			break
+		}
+		i++
+	}
+
+	return strings.Join(parts, "."), i
+}
+
+// ddlList returns the items of the list in parentheses that starts at tokens[open], and the index after the list.
+func ddlList(ddl string, tokens []ddlToken, open int) ([]ddlDefinition, int) {
+	var (
+		defs  []ddlDefinition
+		depth int
+		start = open + 1
+	)
+	for i := open; i < len(tokens); i++ {
+		switch tokens[i].norm {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		case ",":
+			if depth == 1 {
+				defs = appendDefinition(defs, ddl, tokens[start:i])
+				start = i + 1
+			}
+			continue
+		default:
+			continue
+		}
+		if depth == 0 {
+			return appendDefinition(defs, ddl, tokens[start:i]), i + 1
+		}
+	}
+
+	return appendDefinition(defs, ddl, tokens[start:]), len(tokens)
+}
+
+// ddlClauses returns the clauses separated by the commas.
+func ddlClauses(ddl string, tokens []ddlToken) []ddlDefinition {
+	var (
+		defs  []ddlDefinition
+		depth int
+		start int
+	)
+	for i, t := range tokens {
+		switch t.norm {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		case ",":
+			if depth == 0 {
+				defs = appendDefinition(defs, ddl, tokens[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return appendDefinition(defs, ddl, tokens[start:])
+}
+
+func appendDefinition(defs []ddlDefinition, ddl string, tokens []ddlToken) []ddlDefinition {
+	if len(tokens) == 0 {
+		return defs
+	}
+
+	return append(defs, ddlDefinition{
+		src:  ddl[tokens[0].pos:tokens[len(tokens)-1].end],
+		norm: normDDL(tokens),
+	})
+}
+
+// normDDL returns the compared text of the tokens.
+func normDDL(tokens []ddlToken) string {
+	norms := make([]string, len(tokens))
+	for i, t := range tokens {
+		norms[i] = t.norm
+	}
+
+	return strings.Join(norms, " ")
+}
+
+// lexDDL splits the DDL script into the tokens, removing the whitespaces and the comments.
+func lexDDL(ddl string, d Dialect) ([]ddlToken, error) {
+	fold := strings.ToUpper
+	if d == PostgreSQL {
+		fold = strings.ToLower
+	}
+
+	var tokens []ddlToken
+	for i := 0; i < len(ddl); {
+		c := ddl[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(ddl[i:], "--") || (c == '#' && d == GoogleSQL):
+			end := strings.IndexByte(ddl[i:], '\n')
+			if end < 0 {
+				end = len(ddl) - i
+			}
+			i += end
+		case strings.HasPrefix(ddl[i:], "/*"):
+			end := strings.Index(ddl[i+2:], "*/")
+			if end < 0 {
+				return nil, errors.Errorf("unterminated comment at offset %d", i)
+			}
+			i += end + 4
+		case isDDLWordChar(c):
+			end := i
+			for end < len(ddl) && isDDLWordChar(ddl[end]) {
+				end++
+			}
+			tokens = append(tokens, ddlToken{kind: ddlWord, text: ddl[i:end], norm: fold(ddl[i:end]), pos: i, end: end})
+			i = end
+		case c == '`' || c == '\'' || c == '"':
+			end, err := scanDDLQuoted(ddl, i, d)
+			if err != nil {
+				return nil, err
+			}
+			t := ddlToken{kind: ddlString, text: ddl[i:end], norm: ddl[i:end], pos: i, end: end}
+			switch {
+			case c == '`':
+				t.kind, t.text = ddlIdentifier, ddl[i+1:end-1]
+				t.norm = fold(t.text)
+			case c == '"' && d == PostgreSQL:
+				t.kind, t.text = ddlIdentifier, strings.Replace(ddl[i+1:end-1], `""`, `"`, -1)
+				t.norm = t.text
+			}
+			tokens = append(tokens, t)
+			i = end
+		default:
+			tokens = append(tokens, ddlToken{kind: ddlPunct, text: string(c), norm: string(c), pos: i, end: i + 1})
+			i++
+		}
+	}
+
+	return tokens, nil
+}
+
+// scanDDLQuoted returns the offset after the quoted string or identifier that starts at ddl[start].
+// The quote is escaped by the backslash in GoogleSQL and by doubling it in PostgreSQL.
+func scanDDLQuoted(ddl string, start int, d Dialect) (int, error) {
+	q := ddl[start]
+	for i := start + 1; i < len(ddl); i++ {
+		switch {
+		case ddl[i] == '\\' && d == GoogleSQL:
+			i++
+		case ddl[i] == q && d == PostgreSQL && i+1 < len(ddl) && ddl[i+1] == q:
+			i++
+		case ddl[i] == q:
+			return i + 1, nil
+		}
+	}
+
+	return 0, errors.Errorf("unterminated %c at offset %d", q, start)
+}
+
+func isDDLWordChar(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,184 @@
+package spoon_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+func TestSchema_CompareDDL(t *testing.T) {
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+	s, err := cli.BuildSchema([]spoon.EntityBehavior{spoon.Entity(&SchemaChild{}), spoon.Entity(&SchemaParent{})})
+	if err != nil {
+		t.Fatalf("error build schema %#v", err)
+	}
+
+	tests := []struct {
+		name   string
+		ddl    string
+		expect spoon.Drifts
+	}{
+		{
+			name: "same schema in another format",
+			ddl: `-- committed schema
+create index SchemaParentByName on schemaparent (Name);
+
+CREATE TABLE IF NOT EXISTS SchemaChild (ChildID INT64 NOT NULL, ID INT64 NOT NULL)
+  INTERLEAVE IN PARENT SchemaParent, PRIMARY KEY (ID, ChildID);
+
+/* parent */
+CREATE TABLE ` + "`SchemaParent`" + ` (
+  Name STRING(MAX) NOT NULL,
+  ID INT64 NOT NULL
+) PRIMARY KEY (ID)
+`,
+		},
+		{
+			name: "changed column, missing index and extra table",
+			ddl: `CREATE TABLE SchemaParent (
+  ID INT64 NOT NULL,
+  Name STRING(1024) NOT NULL,
+) PRIMARY KEY (ID);
+
+CREATE TABLE SchemaChild (
+  ID INT64 NOT NULL,
+  ChildID INT64 NOT NULL,
+) PRIMARY KEY (ID, ChildID), INTERLEAVE IN PARENT SchemaParent;
+
+CREATE TABLE Legacy (
+  ID INT64 NOT NULL,
+) PRIMARY KEY (ID);
+`,
+			expect: spoon.Drifts{
+				{
+					Object:  "TABLE SchemaParent",
+					Missing: []string{"`Name` STRING(MAX) NOT NULL"},
+					Extra:   []string{"Name STRING(1024) NOT NULL"},
+				},
+				{
+					Object:  "INDEX SchemaParentByName",
+					Missing: []string{"CREATE INDEX `SchemaParentByName` ON `SchemaParent` (`Name`)"},
+				},
+				{
+					Object: "TABLE Legacy",
+					Extra:  []string{"CREATE TABLE Legacy (\n  ID INT64 NOT NULL,\n) PRIMARY KEY (ID)"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		ds, err := s.CompareDDL(tt.ddl)
+		if err != nil {
+			t.Fatalf("%s: error CompareDDL: %v", tt.name, err)
+		}
+		if diff := cmp.Diff(tt.expect, ds); diff != "" {
+			t.Errorf("%s: Diff:\n%s", tt.name, diff)
+		}
+	}
+}
+
+func TestSchema_CompareDDL_Error(t *testing.T) {
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+	s, err := cli.BuildSchema([]spoon.EntityBehavior{spoon.Entity(&SchemaChild{}), spoon.Entity(&SchemaParent{})})
+	if err != nil {
+		t.Fatalf("error build schema %#v", err)
+	}
+
+	tests := []struct {
+		ddl    string
+		expect string
+	}{
+		{ddl: "IF EXISTS;", expect: `empty statement "IF EXISTS"`},
+		{ddl: "IF NOT EXISTS", expect: `empty statement "IF NOT EXISTS"`},
+		{ddl: "/* unterminated", expect: "unterminated comment at offset 0"},
+	}
+
+	for _, tt := range tests {
+		_, err := s.CompareDDL(tt.ddl)
+		if err == nil {
+			t.Errorf("%q: expected error", tt.ddl)
+			continue
+		}
+		if diff := cmp.Diff(tt.expect, err.Error()); diff != "" {
+			t.Errorf("%q: Diff:\n%s", tt.ddl, diff)
+		}
+	}
+}
+
+func TestDrifts_String(t *testing.T) {
+	ds := spoon.Drifts{
+		{
+			Object:  "TABLE Singer",
+			Missing: []string{"`Name` STRING(MAX) NOT NULL"},
+			Extra:   []string{"Name STRING(1024) NOT NULL"},
+		},
+		{
+			Object:  "INDEX SingerByName",
+			Missing: []string{"CREATE INDEX `SingerByName`\nON `Singer` (`Name`)"},
+		},
+	}
+
+	expect := "TABLE Singer\n" +
+		"- Name STRING(1024) NOT NULL\n" +
+		"+ `Name` STRING(MAX) NOT NULL\n" +
+		"\n" +
+		"INDEX SingerByName\n" +
+		"+ CREATE INDEX `SingerByName`\n" +
+		"+ ON `Singer` (`Name`)\n"
+	if diff := cmp.Diff(expect, ds.String()); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+}
+
+func TestCheckSchema_PostgreSQL(t *testing.T) {
+	cli, err := spoon.New(
+		spoon.WithDialect(spoon.PostgreSQL),
+		spoon.WithSequences(spoon.AddSequence("Test9Seq", spoon.SkipRange(1, 1000))),
+	)
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	ddl := `create sequence "Test9Seq" bit_reversed_positive skip range 1 1000;
+create table "Test9" (
+  "ID" bigint not null default nextval('"Test9Seq"'),
+  "Name" varchar(255) not null,
+  "Tags" varchar(16)[] not null,
+  "Note" varchar,
+  "CreatedAt" timestamptz not null,
+  primary key ("ID")
+);
+create table "Test9Child" (
+  primary key ("ID", "ChildID"),
+  "Payload" bytea,
+  "ID" bigint not null,
+  ChildID bigint not null
+) interleave in parent "Test9";
+create index "Test9ByNote" on "Test9" ("Note");
+create unique index "Test9ChildByPayload" on "Test9Child" ("Payload" desc) where "Payload" is not null;
+`
+	ds, err := cli.CheckSchema([]spoon.EntityBehavior{spoon.Entity(&Test9Child{}), spoon.Entity(&Test9{})}, ddl)
+	if err != nil {
+		t.Fatalf("error CheckSchema: %v", err)
+	}
+
+	// The unquoted identifier is folded to lower case in PostgreSQL.
+	expect := spoon.Drifts{
+		{
+			Object:  "TABLE Test9Child",
+			Missing: []string{`"ChildID" bigint NOT NULL`},
+			Extra:   []string{"ChildID bigint not null"},
+		},
+	}
+	if diff := cmp.Diff(expect, ds); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+}
@@ -0,0 +1,132 @@
+package spoon
+
+import (
+	"strings"
+)
+
+// format holds how DDL statements are rendered.
+type format struct {
+	terminator    string
+	blankLines    int
+	indent        int
+	trailingComma bool
+	header        string
+	ifNotExists   bool
+	ifExists      bool
+	dialect       Dialect
+}
+
+func defaultFormat() *format {
+	return &format{
+		terminator:    ";",
+		blankLines:    1,
+		indent:        4,
+		trailingComma: true,
+	}
+}
+
+func newFormat(opts []FormatOption) *format {
+	f := defaultFormat()
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// FormatOption sets how DDL statements are rendered.
+type FormatOption func(*format)
+
+// Terminator sets the string written after each statement. Default is `;`.
+func Terminator(t string) FormatOption {
+	return func(f *format) {
+		f.terminator = t
+	}
+}
+
+// BlankLines sets the number of blank lines between statements. Default is 1.
+func BlankLines(n int) FormatOption {
+	return func(f *format) {
+		f.blankLines = n
+	}
+}
+
+// IndentWidth sets the indentation width of the columns in `CREATE TABLE`. Default is 4.
+func IndentWidth(n int) FormatOption {
+	return func(f *format) {
+		f.indent = n
+	}
+}
+
+// TrailingComma sets whether a comma is written after the last column in `CREATE TABLE`. Default is true.
+func TrailingComma(enabled bool) FormatOption {
+	return func(f *format) {
+		f.trailingComma = enabled
+	}
+}
+
+// Header sets the comment written at the beginning. Each line is prefixed with `-- `.
+func Header(comment string) FormatOption {
+	return func(f *format) {
+		f.header = comment
+	}
+}
+
+// IfNotExists sets whether `CREATE` statements of tables, indexes and sequences are the `IF NOT EXISTS` variants. Default is false.
+func IfNotExists(enabled bool) FormatOption {
+	return func(f *format) {
+		f.ifNotExists = enabled
+	}
+}
+
+// IfExists sets whether `DROP` statements of tables, indexes and sequences are the `IF EXISTS` variants. Default is false.
+func IfExists(enabled bool) FormatOption {
+	return func(f *format) {
+		f.ifExists = enabled
+	}
+}
+
+// OutputDialect sets the dialect of the statements. Default is GoogleSQL.
+// The calls that return an error, such as Client.WriteSchema, return it for an unknown dialect.
+func OutputDialect(d Dialect) FormatOption {
+	return func(f *format) {
+		f.dialect = d
+	}
+}
+
+func (f *format) indentString() string {
+	return strings.Repeat(" ", f.indent)
+}
+
+func (f *format) quote(unquoted string) string {
+	return f.dialect.quote(unquoted)
+}
+
+func (f *format) createClause(object string) string {
+	if f.ifNotExists {
+		return "CREATE " + object + " IF NOT EXISTS"
+	}
+
+	return "CREATE " + object
+}
+
+func (f *format) dropClause(object string) string {
+	if f.ifExists {
+		return "DROP " + object + " IF EXISTS"
+	}
+
+	return "DROP " + object
+}
+
+func (f *format) headerLines() string {
+	if f.header == "" {
+		return ""
+	}
+
+	lines := strings.Split(f.header, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight("-- "+lines[i], " ")
+	}
+
+	return strings.Join(lines, "\n") + "\n" + strings.Repeat("\n", f.blankLines)
+}
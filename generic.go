@@ -0,0 +1,130 @@
+package spoon
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// EntityError is the error occurred while parsing the Entity type.
+type EntityError struct {
+	Type reflect.Type
+	Err  error
+}
+
+func (e *EntityError) Error() string {
+	return fmt.Sprintf("spoon: entity %s: %s", e.Type, e.Err)
+}
+
+// Cause returns the underlying error.
+func (e *EntityError) Cause() error {
+	return e.Err
+}
+
+// Unwrap returns the underlying error.
+func (e *EntityError) Unwrap() error {
+	return e.Err
+}
+
+// TableFor is Table parsed from the Entity type T.
+// It is parsed once by TableOf, and provides the helpers typed by T.
+type TableFor[T TableBehavior] struct {
+	*Table
+}
+
+// TableOf parses the Entity type T into TableFor.
+// T can be either a struct type or a pointer to struct type, and its value does not need to be set.
+func TableOf[T TableBehavior](c *Client) (*TableFor[T], error) {
+	var zero T
+	rt := reflect.TypeOf(&zero).Elem()
+
+	eb := zero
+	if rt.Kind() == reflect.Ptr {
+		// the methods with pointer receivers are called on the empty value instead of nil.
+		eb = reflect.New(rt.Elem()).Interface().(T)
+	}
+
+	t, err := c.parser.Parse(eb)
+	if err != nil {
+		return nil, &EntityError{Type: rt, Err: err}
+	}
+
+	return &TableFor[T]{Table: t}, nil
+}
+
+// MustTableOf is like TableOf but panics if the Entity type T cannot be parsed.
+func MustTableOf[T TableBehavior](c *Client) *TableFor[T] {
+	t, err := TableOf[T](c)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// ColumnNames returns the column names in order of the fields.
+func (t *TableFor[T]) ColumnNames() []string {
+	names := make([]string, 0, len(t.columns))
+	for _, c := range t.columns {
+		names = append(names, c.name)
+	}
+
+	return names
+}
+
+// Values returns the field values of the Entity in order of ColumnNames.
+func (t *TableFor[T]) Values(v T) ([]interface{}, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		return nil, &EntityError{Type: reflect.TypeOf(v), Err: errors.New("value is nil")}
+	}
+
+	values := make([]interface{}, 0, len(t.columns))
+	for _, c := range t.columns {
+		fv, err := fieldValue(rv, c)
+		if err != nil {
+			return nil, &EntityError{Type: reflect.TypeOf(v), Err: err}
+		}
+		values = append(values, fv)
+	}
+
+	return values, nil
+}
+
+// Key returns the primary key values of the Entity in order of the key parts.
+// It can be converted to `spanner.Key`.
+func (t *TableFor[T]) Key(v T) ([]interface{}, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		return nil, &EntityError{Type: reflect.TypeOf(v), Err: errors.New("value is nil")}
+	}
+
+	names := t.primaryKey.columnNames()
+	key := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		c := t.Column(name)
+		if c == nil {
+			return nil, &EntityError{Type: reflect.TypeOf(v), Err: errors.Errorf("primary key column %s does not exist", name)}
+		}
+		fv, err := fieldValue(rv, c)
+		if err != nil {
+			return nil, &EntityError{Type: reflect.TypeOf(v), Err: err}
+		}
+		key = append(key, fv)
+	}
+
+	return key, nil
+}
+
+func fieldValue(rv reflect.Value, c *Column) (interface{}, error) {
+	fv, err := rv.FieldByIndexErr(c.fieldIndex)
+	if err != nil {
+		return nil, errors.Wrapf(err, "column %s", c.name)
+	}
+	if !fv.CanInterface() {
+		return nil, errors.Errorf("column %s is unexported field", c.name)
+	}
+
+	return fv.Interface(), nil
+}
@@ -0,0 +1,78 @@
+package spoon_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+type GenericChild struct {
+	CreatedAt time.Time
+}
+
+type GenericEntity struct {
+	ID   int64  `db:"pk=1"`
+	Kind string `db:"pk=2"`
+	Name string
+	*GenericChild
+}
+
+func (g *GenericEntity) TableName() string {
+	return "GenericEntity"
+}
+
+type GenericInvalid struct {
+	ID int64
+}
+
+func (g GenericInvalid) TableName() string {
+	return "GenericInvalid"
+}
+
+func TestTableOf(t *testing.T) {
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	tbl, err := spoon.TableOf[*GenericEntity](cli)
+	if err != nil {
+		t.Fatalf("error table of %#v", err)
+	}
+
+	if diff := cmp.Diff([]string{"ID", "Kind", "Name", "CreatedAt"}, tbl.ColumnNames()); diff != "" {
+		t.Errorf("ColumnNames Diff:\n%s", diff)
+	}
+
+	now := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	v := &GenericEntity{ID: 1, Kind: "a", Name: "n", GenericChild: &GenericChild{CreatedAt: now}}
+
+	key, err := tbl.Key(v)
+	if err != nil {
+		t.Fatalf("error key %#v", err)
+	}
+	if diff := cmp.Diff([]interface{}{int64(1), "a"}, key); diff != "" {
+		t.Errorf("Key Diff:\n%s", diff)
+	}
+
+	values, err := tbl.Values(v)
+	if err != nil {
+		t.Fatalf("error values %#v", err)
+	}
+	if diff := cmp.Diff([]interface{}{int64(1), "a", "n", now}, values); diff != "" {
+		t.Errorf("Values Diff:\n%s", diff)
+	}
+
+	if _, err := tbl.Values(&GenericEntity{ID: 1}); err == nil {
+		t.Errorf("expect error nil nested struct")
+	}
+
+	_, err = spoon.TableOf[GenericInvalid](cli)
+	var ee *spoon.EntityError
+	if !errors.As(err, &ee) {
+		t.Fatalf("expect EntityError, but %#v", err)
+	}
+}
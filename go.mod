@@ -1,15 +1,24 @@
 module github.com/pi9min/spoon
 
+go 1.22.0
+
 require (
 	cloud.google.com/go v0.31.0
-	github.com/google/go-cmp v0.2.0
-	github.com/googleapis/gax-go v2.0.2+incompatible // indirect
+	github.com/google/go-cmp v0.6.0
 	github.com/pkg/errors v0.8.0
+	golang.org/x/sync v0.11.0
+	golang.org/x/tools v0.30.0
+)
+
+require (
+	github.com/golang/protobuf v1.2.0 // indirect
+	github.com/googleapis/gax-go v2.0.2+incompatible // indirect
 	go.opencensus.io v0.18.0 // indirect
-	golang.org/x/net v0.0.0-20181023162649-9b4f9f5ad519 // indirect
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/net v0.35.0 // indirect
 	golang.org/x/oauth2 v0.0.0-20181017192945-9dcd33a902f4 // indirect
-	golang.org/x/sync v0.0.0-20180314180146-1d60e4601c6f
-	golang.org/x/sys v0.0.0-20181023152157-44b849a8bc13 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+	golang.org/x/text v0.22.0 // indirect
 	google.golang.org/api v0.0.0-20181021000519-a2651947f503 // indirect
 	google.golang.org/appengine v1.2.0 // indirect
 	google.golang.org/genproto v0.0.0-20181016170114-94acd270e44e // indirect
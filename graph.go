@@ -0,0 +1,206 @@
+package spoon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// PropertyGraph holds the node and edge tables of Spanner Graph.
+type PropertyGraph struct {
+	name  string
+	nodes []*GraphNode
+	edges []*GraphEdge
+}
+
+// GraphNode holds the Entity used as node table.
+type GraphNode struct {
+	entity     EntityBehavior
+	label      string
+	properties []string
+}
+
+// GraphEdge holds the Entity used as edge table and the node tables it connects.
+type GraphEdge struct {
+	entity      EntityBehavior
+	label       string
+	source      GraphReference
+	destination GraphReference
+	properties  []string
+}
+
+// GraphReference holds the columns of the edge table that reference the primary key of the node Entity.
+type GraphReference struct {
+	Entity  EntityBehavior
+	Columns []string
+}
+
+// AddPropertyGraph creates PropertyGraph.
+func AddPropertyGraph(name string, nodes []*GraphNode, edges []*GraphEdge) *PropertyGraph {
+	return &PropertyGraph{
+		name:  name,
+		nodes: nodes,
+		edges: edges,
+	}
+}
+
+// AddGraphNode creates GraphNode.
+// If the label is empty, the default label is used. If the properties are empty, all columns are used.
+func AddGraphNode(eb EntityBehavior, label string, properties ...string) *GraphNode {
+	return &GraphNode{
+		entity:     eb,
+		label:      label,
+		properties: properties,
+	}
+}
+
+// AddGraphEdge creates GraphEdge.
+// If the label is empty, the default label is used. If the properties are empty, all columns are used.
+func AddGraphEdge(eb EntityBehavior, label string, source, destination GraphReference, properties ...string) *GraphEdge {
+	return &GraphEdge{
+		entity:      eb,
+		label:       label,
+		source:      source,
+		destination: destination,
+		properties:  properties,
+	}
+}
+
+// DropPropertyGraphSchema return `DROP PROPERTY GRAPH` schema.
+func (g *PropertyGraph) DropPropertyGraphSchema() string {
+	return fmt.Sprintf("DROP PROPERTY GRAPH %s", Quote(g.name))
+}
+
+func (g *PropertyGraph) createPropertyGraphSchema(p *parser) (string, error) {
+	nodeTables := make(map[string]*Table, len(g.nodes))
+	ss := []string{fmt.Sprintf("CREATE PROPERTY GRAPH %s", Quote(g.name)), "    NODE TABLES ("}
+	for i, n := range g.nodes {
+		t, err := p.Parse(n.entity)
+		if err != nil {
+			return "", err
+		}
+		nodeTables[t.name] = t
+
+		props, err := graphProperties(t, n.label, n.properties)
+		if err != nil {
+			return "", err
+		}
+		ss = append(ss, fmt.Sprintf("        %s KEY (%s) %s%s", Quote(t.name), quoteColumns(t.primaryKey.columnNames()), props, graphSeparator(i, len(g.nodes))))
+	}
+	ss = append(ss, "    )")
+
+	if len(g.edges) == 0 {
+		return strings.Join(ss, "\n"), nil
+	}
+
+	ss = append(ss, "    EDGE TABLES (")
+	for i, e := range g.edges {
+		t, err := p.Parse(e.entity)
+		if err != nil {
+			return "", err
+		}
+
+		src, err := graphReferenceSchema(p, t, nodeTables, e.source)
+		if err != nil {
+			return "", errors.Wrapf(err, "edge %s: source key", t.name)
+		}
+		dst, err := graphReferenceSchema(p, t, nodeTables, e.destination)
+		if err != nil {
+			return "", errors.Wrapf(err, "edge %s: destination key", t.name)
+		}
+		props, err := graphProperties(t, e.label, e.properties)
+		if err != nil {
+			return "", err
+		}
+
+		ss = append(ss,
+			fmt.Sprintf("        %s KEY (%s)", Quote(t.name), quoteColumns(t.primaryKey.columnNames())),
+			fmt.Sprintf("            SOURCE KEY %s", src),
+			fmt.Sprintf("            DESTINATION KEY %s", dst),
+			fmt.Sprintf("            %s%s", props, graphSeparator(i, len(g.edges))),
+		)
+	}
+	ss = append(ss, "    )")
+
+	return strings.Join(ss, "\n"), nil
+}
+
+func graphSeparator(i, n int) string {
+	if i < n-1 {
+		return ","
+	}
+
+	return ""
+}
+
+func graphProperties(t *Table, label string, properties []string) (string, error) {
+	if len(properties) == 0 {
+		properties = make([]string, 0, len(t.columns))
+		for _, c := range t.columns {
+			properties = append(properties, c.name)
+		}
+	}
+	for _, prop := range properties {
+		if t.Column(prop) == nil {
+			return "", errors.Errorf("table %s: property column %s does not exist", t.name, prop)
+		}
+	}
+
+	if label == "" {
+		return fmt.Sprintf("DEFAULT LABEL PROPERTIES (%s)", quoteColumns(properties)), nil
+	}
+
+	return fmt.Sprintf("LABEL %s PROPERTIES (%s)", Quote(label), quoteColumns(properties)), nil
+}
+
+func graphReferenceSchema(p *parser, edge *Table, nodeTables map[string]*Table, ref GraphReference) (string, error) {
+	if ref.Entity == nil {
+		return "", errors.New("referenced entity is nil")
+	}
+	rt, err := p.Parse(ref.Entity)
+	if err != nil {
+		return "", err
+	}
+	node, ok := nodeTables[rt.name]
+	if !ok {
+		return "", errors.Errorf("table %s is not a node table", rt.name)
+	}
+
+	keys := node.primaryKey.columnNames()
+	if len(ref.Columns) != len(keys) {
+		return "", errors.Errorf("%d columns do not match the primary key of %s (%d columns)", len(ref.Columns), node.name, len(keys))
+	}
+	for i, name := range ref.Columns {
+		c := edge.Column(name)
+		if c == nil {
+			return "", errors.Errorf("column %s does not exist", name)
+		}
+		kc := node.Column(keys[i])
+		if kc == nil {
+			return "", errors.Errorf("primary key column %s does not exist in %s", keys[i], node.name)
+		}
+		ct, kt := baseTypeString(c), baseTypeString(kc)
+		if ct != kt {
+			return "", errors.Errorf("column %s type %s does not match %s.%s type %s", name, ct, node.name, keys[i], kt)
+		}
+	}
+
+	return fmt.Sprintf("(%s) REFERENCES %s (%s)", quoteColumns(ref.Columns), Quote(node.name), quoteColumns(keys)), nil
+}
+
+// baseTypeString returns the type of the column ignoring the size.
+func baseTypeString(c *Column) string {
+	ct, _ := c.columnType()
+	ct.size = 0
+	return GoogleSQL.typeString(ct)
+}
+
+func quoteColumns(names []string) string {
+	ss := make([]string, 0, len(names))
+	for _, n := range names {
+		ss = append(ss, Quote(n))
+	}
+
+	return strings.Join(ss, ", ")
+}
@@ -0,0 +1,171 @@
+package spoon_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+type GraphUser struct {
+	ID   int64
+	Name string
+}
+
+func (u *GraphUser) TableName() string {
+	return "User"
+}
+
+func (u *GraphUser) Indexes() spoon.Indexes {
+	return spoon.Indexes{}
+}
+
+func (u *GraphUser) PrimaryKey() *spoon.PrimaryKey {
+	return spoon.AddPrimaryKey(spoon.KeyPart{ColumnName: "ID"})
+}
+
+type GraphFollow struct {
+	UserID     int64
+	FolloweeID int64
+	Note       string
+	CreatedAt  time.Time
+}
+
+func (f *GraphFollow) TableName() string {
+	return "Follow"
+}
+
+func (f *GraphFollow) Indexes() spoon.Indexes {
+	return spoon.Indexes{}
+}
+
+func (f *GraphFollow) PrimaryKey() *spoon.PrimaryKey {
+	return spoon.AddPrimaryKeyWithInterleave("User", spoon.KeyPart{ColumnName: "UserID"}, spoon.KeyPart{ColumnName: "FolloweeID"})
+}
+
+func TestGenerateCreatePropertyGraph(t *testing.T) {
+	tests := []struct {
+		name      string
+		graph     *spoon.PropertyGraph
+		expect    string
+		expectErr bool
+	}{
+		{
+			name: "1 node and edge",
+			graph: spoon.AddPropertyGraph(
+				"SocialGraph",
+				[]*spoon.GraphNode{
+					spoon.AddGraphNode(&GraphUser{}, "Person"),
+				},
+				[]*spoon.GraphEdge{
+					spoon.AddGraphEdge(
+						&GraphFollow{},
+						"Follows",
+						spoon.GraphReference{Entity: &GraphUser{}, Columns: []string{"UserID"}},
+						spoon.GraphReference{Entity: &GraphUser{}, Columns: []string{"FolloweeID"}},
+						"CreatedAt",
+					),
+				},
+			),
+			expect: `CREATE PROPERTY GRAPH ` + "`SocialGraph`" + `
+    NODE TABLES (
+        ` + "`User` KEY (`ID`) LABEL `Person` PROPERTIES (`ID`, `Name`)" + `
+    )
+    EDGE TABLES (
+        ` + "`Follow` KEY (`UserID`, `FolloweeID`)" + `
+            SOURCE KEY ` + "(`UserID`) REFERENCES `User` (`ID`)" + `
+            DESTINATION KEY ` + "(`FolloweeID`) REFERENCES `User` (`ID`)" + `
+            LABEL ` + "`Follows` PROPERTIES (`CreatedAt`)" + `
+    )`,
+		},
+		{
+			name: "2 node only with default label",
+			graph: spoon.AddPropertyGraph(
+				"UserGraph",
+				[]*spoon.GraphNode{
+					spoon.AddGraphNode(&GraphUser{}, "", "Name"),
+				},
+				nil,
+			),
+			expect: "CREATE PROPERTY GRAPH `UserGraph`\n    NODE TABLES (\n        `User` KEY (`ID`) DEFAULT LABEL PROPERTIES (`Name`)\n    )",
+		},
+		{
+			name: "3 key type mismatch",
+			graph: spoon.AddPropertyGraph(
+				"SocialGraph",
+				[]*spoon.GraphNode{
+					spoon.AddGraphNode(&GraphUser{}, "Person"),
+				},
+				[]*spoon.GraphEdge{
+					spoon.AddGraphEdge(
+						&GraphFollow{},
+						"Follows",
+						spoon.GraphReference{Entity: &GraphUser{}, Columns: []string{"Note"}},
+						spoon.GraphReference{Entity: &GraphUser{}, Columns: []string{"FolloweeID"}},
+					),
+				},
+			),
+			expectErr: true,
+		},
+		{
+			name: "4 key count mismatch",
+			graph: spoon.AddPropertyGraph(
+				"SocialGraph",
+				[]*spoon.GraphNode{
+					spoon.AddGraphNode(&GraphUser{}, "Person"),
+				},
+				[]*spoon.GraphEdge{
+					spoon.AddGraphEdge(
+						&GraphFollow{},
+						"Follows",
+						spoon.GraphReference{Entity: &GraphUser{}, Columns: []string{"UserID", "FolloweeID"}},
+						spoon.GraphReference{Entity: &GraphUser{}, Columns: []string{"FolloweeID"}},
+					),
+				},
+			),
+			expectErr: true,
+		},
+		{
+			name: "5 reference to non node table",
+			graph: spoon.AddPropertyGraph(
+				"SocialGraph",
+				[]*spoon.GraphNode{
+					spoon.AddGraphNode(&GraphUser{}, "Person"),
+				},
+				[]*spoon.GraphEdge{
+					spoon.AddGraphEdge(
+						&GraphFollow{},
+						"Follows",
+						spoon.GraphReference{Entity: &GraphUser{}, Columns: []string{"UserID"}},
+						spoon.GraphReference{Entity: &Test1{}, Columns: []string{"FolloweeID"}},
+					),
+				},
+			),
+			expectErr: true,
+		},
+	}
+
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := cli.GenerateCreatePropertyGraph(tt.graph)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expect error, but nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error generate property graph %#v", err)
+			}
+			if diff := cmp.Diff(tt.expect, actual); diff != "" {
+				t.Errorf("GenerateCreatePropertyGraph Diff:\n%s", diff)
+			}
+		})
+	}
+}
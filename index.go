@@ -2,7 +2,11 @@ package spoon
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/pkg/errors"
 )
 
 // Indexes are alias of index slices.
@@ -17,42 +21,87 @@ type Index struct {
 	keyParts     []KeyPart
 }
 
+// Name returns the index name.
+func (i *Index) Name() string {
+	return i.name
+}
+
+// TableName returns the indexed table name.
+func (i *Index) TableName() string {
+	return i.tableName
+}
+
+// IsUnique reports whether the index is unique.
+func (i *Index) IsUnique() bool {
+	return i.isUnique
+}
+
+// IsNullFiltered reports whether the index is null filtered.
+func (i *Index) IsNullFiltered() bool {
+	return i.nullFiltered
+}
+
+// KeyParts returns the key parts.
+func (i *Index) KeyParts() []KeyPart {
+	return append([]KeyPart(nil), i.keyParts...)
+}
+
 // CreateIndexSchema return `CREATE INDEX` schema.
-func (i *Index) CreateIndexSchema() string {
+func (i *Index) CreateIndexSchema(opts ...FormatOption) string {
+	return i.createIndexSchema(newFormat(opts))
+}
+
+func (i *Index) createIndexSchema(f *format) string {
 	var keyPartsStr []string
 
 	for _, kp := range i.keyParts {
-		kps := Quote(kp.ColumnName)
+		kps := f.quote(kp.ColumnName)
 		if kp.IsOrderDesc {
 			kps += " DESC"
 		}
 		keyPartsStr = append(keyPartsStr, kps)
 	}
 
-	words := make([]string, 0, 4)
+	words := make([]string, 0, 5)
 	words = append(words, "CREATE")
 	if i.isUnique {
 		words = append(words, "UNIQUE")
 	}
-	if i.nullFiltered {
+	if i.nullFiltered && f.dialect == GoogleSQL {
 		words = append(words, "NULL_FILTERED")
 	}
 	words = append(words, "INDEX")
+	if f.ifNotExists {
+		words = append(words, "IF NOT EXISTS")
+	}
 
 	schema := fmt.Sprintf(
 		"%s %s ON %s (%s)",
 		strings.Join(words, " "),
-		Quote(i.name),
-		Quote(i.tableName),
+		f.quote(i.name),
+		f.quote(i.tableName),
 		strings.Join(keyPartsStr, ", "),
 	)
 
+	// PostgreSQL dialect filters nulls by the WHERE clause on all key columns.
+	if i.nullFiltered && f.dialect == PostgreSQL {
+		conds := make([]string, 0, len(i.keyParts))
+		for _, kp := range i.keyParts {
+			conds = append(conds, f.quote(kp.ColumnName)+" IS NOT NULL")
+		}
+		schema += " WHERE " + strings.Join(conds, " AND ")
+	}
+
 	return schema
 }
 
 // DropIndexSchema return `DROP INDEX` schema.
-func (i *Index) DropIndexSchema() string {
-	schema := fmt.Sprintf("DROP INDEX %s", Quote(i.name))
+func (i *Index) DropIndexSchema(opts ...FormatOption) string {
+	return i.dropIndexSchema(newFormat(opts))
+}
+
+func (i *Index) dropIndexSchema(f *format) string {
+	schema := fmt.Sprintf("%s %s", f.dropClause("INDEX"), f.quote(i.name))
 	return schema
 }
 
@@ -77,3 +126,111 @@ func AddUniqueIndex(idxName, tableName string, nullFiltered bool, keyParts ...Ke
 		nullFiltered: nullFiltered,
 	}
 }
+
+const (
+	indexTagKey       = "index"
+	uniqueIndexTagKey = "unique_index"
+)
+
+// indexTag holds the key part declared by the `index` or `unique_index` tag.
+// The tag value is `[<name>][:<position>][:desc]`, and order is 0 when the position is not specified.
+type indexTag struct {
+	name        string
+	isUnique    bool
+	order       int
+	isOrderDesc bool
+}
+
+func isIndexTag(tag string) bool {
+	key := strings.SplitN(tag, "=", 2)[0]
+	return key == indexTagKey || key == uniqueIndexTagKey
+}
+
+func parseIndexTag(tag string) (*indexTag, error) {
+	kv := strings.SplitN(tag, "=", 2)
+	it := &indexTag{
+		isUnique: kv[0] == uniqueIndexTagKey,
+	}
+	if len(kv) == 1 {
+		return it, nil
+	}
+
+	ss := strings.Split(kv[1], ":")
+	it.name = ss[0]
+	for _, s := range ss[1:] {
+		if s == "desc" {
+			it.isOrderDesc = true
+			continue
+		}
+
+		order, err := strconv.Atoi(s)
+		if err != nil || order < 1 {
+			return nil, errors.Errorf("invalid %s position %s", kv[0], s)
+		}
+		it.order = order
+	}
+
+	return it, nil
+}
+
+// tagIndexes creates Indexes from the index tags of the columns.
+// The unnamed index is named `<Table>By<Column>`.
+func tagIndexes(tableName string, columns []*Column) (Indexes, error) {
+	type indexColumn struct {
+		column *Column
+		tag    *indexTag
+	}
+
+	var names []string
+	byName := make(map[string][]indexColumn)
+	for _, c := range columns {
+		for _, it := range c.indexes {
+			name := it.name
+			if name == "" {
+				name = tableName + "By" + c.name
+			}
+			if _, ok := byName[name]; !ok {
+				names = append(names, name)
+			}
+			byName[name] = append(byName[name], indexColumn{column: c, tag: it})
+		}
+	}
+
+	indexes := make(Indexes, 0, len(names))
+	for _, name := range names {
+		ics := byName[name]
+
+		// All positions must be specified, or none of them.
+		isUnique := ics[0].tag.isUnique
+		ordered := ics[0].tag.order != 0
+		orders := make(map[int]bool, len(ics))
+		for _, ic := range ics {
+			if ic.tag.isUnique != isUnique {
+				return nil, errors.Errorf("index %s: field %s mixes index and unique_index", name, ic.column.name)
+			}
+			if (ic.tag.order != 0) != ordered {
+				return nil, errors.Errorf("index %s: position must be specified for all key parts or none", name)
+			}
+			if ordered && orders[ic.tag.order] {
+				return nil, errors.Errorf("index %s: duplicate position %d", name, ic.tag.order)
+			}
+			orders[ic.tag.order] = true
+		}
+		sort.SliceStable(ics, func(i, j int) bool {
+			return ics[i].tag.order < ics[j].tag.order
+		})
+
+		keyParts := make([]KeyPart, 0, len(ics))
+		for _, ic := range ics {
+			keyParts = append(keyParts, KeyPart{ColumnName: ic.column.name, IsOrderDesc: ic.tag.isOrderDesc})
+		}
+
+		if isUnique {
+			indexes = append(indexes, AddUniqueIndex(name, tableName, false, keyParts...))
+		} else {
+			indexes = append(indexes, AddIndex(name, tableName, false, keyParts...))
+		}
+	}
+
+	return indexes, nil
+}
@@ -7,6 +7,19 @@ import (
 	"github.com/pi9min/spoon"
 )
 
+func TestIndex_IfNotExists(t *testing.T) {
+	idx := spoon.AddUniqueIndex("PlayerByUniquePlayerID", "Player", true, spoon.KeyPart{ColumnName: "PlayerID"})
+	if diff := cmp.Diff("CREATE UNIQUE NULL_FILTERED INDEX IF NOT EXISTS `PlayerByUniquePlayerID` ON `Player` (`PlayerID`)", idx.CreateIndexSchema(spoon.IfNotExists(true))); diff != "" {
+		t.Errorf("CreateIndexSchema Diff:\n%s", diff)
+	}
+	if diff := cmp.Diff("DROP INDEX IF EXISTS `PlayerByUniquePlayerID`", idx.DropIndexSchema(spoon.IfExists(true))); diff != "" {
+		t.Errorf("DropIndexSchema Diff:\n%s", diff)
+	}
+	if diff := cmp.Diff("DROP INDEX `PlayerByUniquePlayerID`", idx.DropIndexSchema(spoon.IfNotExists(true))); diff != "" {
+		t.Errorf("DropIndexSchema Diff:\n%s", diff)
+	}
+}
+
 func TestAddIndex(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -44,3 +57,93 @@ func TestAddIndex(t *testing.T) {
 		})
 	}
 }
+
+type TagIndex1 struct {
+	ID        int64  `db:"pk"`
+	FirstName string `db:"index=TagIndex1ByName:2"`
+	LastName  string `db:"index=TagIndex1ByName:1,index=TagIndex1ByLastNameDesc:desc"`
+	Email     string `db:"unique_index=TagIndex1ByEmail"`
+	Age       int64  `db:"index"`
+}
+
+func (t *TagIndex1) TableName() string {
+	return "TagIndex1"
+}
+
+type TagIndex2 struct {
+	ID    int64  `db:"pk"`
+	Email string `db:"unique_index=TagIndex2ByEmail"`
+}
+
+func (t *TagIndex2) TableName() string {
+	return "TagIndex2"
+}
+
+func (t *TagIndex2) Indexes() spoon.Indexes {
+	return spoon.Indexes{
+		spoon.AddIndex("TagIndex2ByEmail", "TagIndex2", false, spoon.KeyPart{ColumnName: "Email"}),
+	}
+}
+
+type TagIndex3 struct {
+	ID    int64  `db:"pk"`
+	Name  string `db:"index=TagIndex3ByName:1"`
+	Email string `db:"unique_index=TagIndex3ByName:2"`
+}
+
+func (t *TagIndex3) TableName() string {
+	return "TagIndex3"
+}
+
+func TestGenerateCreateIndexes_Tag(t *testing.T) {
+	tests := []struct {
+		name      string
+		entity    spoon.TableBehavior
+		expect    []string
+		expectErr bool
+	}{
+		{
+			name:   "1 index tags",
+			entity: &TagIndex1{},
+			expect: []string{
+				"CREATE INDEX `TagIndex1ByName` ON `TagIndex1` (`LastName`, `FirstName`)",
+				"CREATE INDEX `TagIndex1ByLastNameDesc` ON `TagIndex1` (`LastName` DESC)",
+				"CREATE UNIQUE INDEX `TagIndex1ByEmail` ON `TagIndex1` (`Email`)",
+				"CREATE INDEX `TagIndex1ByAge` ON `TagIndex1` (`Age`)",
+			},
+		},
+		{
+			name:      "2 duplicate index name with Indexes()",
+			entity:    &TagIndex2{},
+			expectErr: true,
+		},
+		{
+			name:      "3 mixed unique",
+			entity:    &TagIndex3{},
+			expectErr: true,
+		},
+	}
+
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := cli.GenerateCreateIndexes(tt.entity)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expect error, but nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error generate create indexes %#v", err)
+			}
+			if diff := cmp.Diff(tt.expect, actual); diff != "" {
+				t.Errorf("GenerateCreateIndexes Diff:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Package bridge gives the packages of this module that parse the Entity without reflection
+// access to the parser of spoon package, which registers it in its init.
+package bridge
+
+import "reflect"
+
+// Type is the Go type of the struct field described independently of reflect and go/types,
+// so the type of the column is derived from it in the same way by both.
+type Type struct {
+	// Kind is the kind of the underlying type.
+	Kind reflect.Kind
+	// Name is the name of the defined type such as Time and NullString.
+	Name string
+	// Elem is the element type of the pointer or the slice.
+	Elem *Type
+}
+
+// Parser parses the Entity by spoon package.
+// The values of type any are *spoon.Column and *spoon.Table, because this package can not import spoon package.
+type Parser interface {
+	// TagPrefix returns the key of the struct tag.
+	TagPrefix() string
+	// ParseField returns the column of the struct field, or nil if the field is ignored by the tag.
+	ParseField(name, tag string, t *Type) (any, error)
+	// LintField returns the error of the struct tag and the type of the column that are accepted but have no effect.
+	LintField(column any, tag string) error
+	// NewTable returns the table of the columns and the Entity whose methods return the declarations.
+	NewTable(entity any, columns []any) (any, error)
+}
+
+var (
+	// ClientParser returns the parser of *spoon.Client.
+	ClientParser func(client any) Parser
+	// NewParser returns the parser of the struct tag prefix and the tag value that ignores the field.
+	NewParser func(tagPrefix, ignoreTag string) Parser
+	// NewSchema returns *spoon.Schema of the tables with the options of *spoon.Client.
+	NewSchema func(client any, tables []any) (any, error)
+)
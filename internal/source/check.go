@@ -0,0 +1,72 @@
+package source
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/pi9min/spoon"
+	"github.com/pkg/errors"
+)
+
+// Check reports the mistakes of the Entity by Report. The declarations that can not be evaluated statically are not checked.
+func (sp *Parser) Check(tn *types.TypeName) {
+	var reported bool
+	report := sp.Report
+	sp.Report = func(e *Error) {
+		reported = true
+		report(e)
+	}
+	defer func() { sp.Report = report }()
+
+	columns, err := sp.parseStructType(tn.Type())
+	if err != nil {
+		sp.Report(sp.errorAt(tn.Pos(), err))
+		return
+	}
+	exists := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		exists[c.Name()] = true
+	}
+
+	tb, d, errs := sp.declare(tn)
+	evaluated := true
+	for _, err := range errs {
+		if err != nil {
+			evaluated = false
+		}
+	}
+
+	if pk := d.primaryKey; pk != nil {
+		sp.checkKeyParts(pk, pk.KeyParts(), exists, "primary key")
+	}
+	for _, idx := range d.indexes {
+		if d.tableName != "" && idx.TableName() != d.tableName {
+			call := sp.calls[idx].call
+			sp.Report(sp.errorAt(call.Args[1].Pos(), errors.Errorf("index %s: table %s does not match TableName() %s", idx.Name(), idx.TableName(), d.tableName)))
+		}
+		sp.checkKeyParts(idx, idx.KeyParts(), exists, "index "+idx.Name())
+	}
+
+	// The declaration is checked as a whole only if every part of it is known.
+	if !evaluated || reported {
+		return
+	}
+	if _, err := sp.newTable(tb, columns); err != nil {
+		sp.Report(sp.errorAt(tn.Pos(), err))
+	}
+}
+
+// checkKeyParts reports the key parts of v whose column does not exist.
+func (sp *Parser) checkKeyParts(v interface{}, kps []spoon.KeyPart, exists map[string]bool, object string) {
+	sc := sp.calls[v]
+	for i, kp := range kps {
+		if exists[kp.ColumnName] {
+			continue
+		}
+		var node ast.Node = sc.call
+		if i < len(sc.keyParts) {
+			node = sc.keyParts[i]
+		}
+		sp.Report(sp.errorAt(node.Pos(), errors.Errorf("%s: column %s does not exist", object, kp.ColumnName)))
+	}
+}
@@ -0,0 +1,303 @@
+package source
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"reflect"
+
+	"github.com/pi9min/spoon"
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// evalMethod evaluates the return value of the method and sets it to ptr.
+// ptr is not changed if the method is not declared.
+func (sp *Parser) evalMethod(tn *types.TypeName, name string, ptr interface{}) error {
+	fn := sp.method(tn, name)
+	if fn == nil {
+		return nil
+	}
+
+	fd, ok := sp.funcs[fn]
+	if !ok {
+		return errors.Errorf("%s.%s: method must be declared in package %s", tn.Name(), name, sp.pkg.Path())
+	}
+	if fd.Body == nil || len(fd.Body.List) != 1 {
+		return sp.errorf(fd, "%s.%s: method must consist of one return statement", tn.Name(), name)
+	}
+	ret, ok := fd.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return sp.errorf(fd, "%s.%s: method must consist of one return statement", tn.Name(), name)
+	}
+
+	v, err := sp.eval(ret.Results[0])
+	if err != nil {
+		return err
+	}
+
+	pv := reflect.ValueOf(ptr).Elem()
+	if v == nil {
+		pv.Set(reflect.Zero(pv.Type()))
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	if !rv.Type().AssignableTo(pv.Type()) {
+		return sp.errorf(ret.Results[0], "%s.%s: unexpected %s", tn.Name(), name, rv.Type())
+	}
+	pv.Set(rv)
+
+	return nil
+}
+
+// eval evaluates the expression of constant values and spoon functions.
+func (sp *Parser) eval(expr ast.Expr) (interface{}, error) {
+	tv := sp.info.Types[expr]
+	if tv.Value != nil {
+		return sp.constant(expr, tv)
+	}
+
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return sp.eval(e.X)
+	case *ast.Ident:
+		if tv.IsNil() {
+			return nil, nil
+		}
+	case *ast.CompositeLit:
+		return sp.evalCompositeLit(e, tv.Type)
+	case *ast.CallExpr:
+		return sp.evalCall(e)
+	case *ast.SelectorExpr:
+		// The field of the struct variable such as `UserColumns.ID` generated by spoongen.
+		if isSpoonType(tv.Type, "ColumnName") {
+			if sel, ok := sp.info.Selections[e]; ok && sel.Kind() == types.FieldVal {
+				return spoon.ColumnName(e.Sel.Name), nil
+			}
+		}
+	}
+
+	return nil, sp.errorf(expr, "unsupported expression %s", types.ExprString(expr))
+}
+
+func (sp *Parser) constant(expr ast.Expr, tv types.TypeAndValue) (interface{}, error) {
+	switch tv.Value.Kind() {
+	case constant.String:
+		s := constant.StringVal(tv.Value)
+		switch {
+		case isSpoonType(tv.Type, "ColumnName"):
+			return spoon.ColumnName(s), nil
+		case isSpoonType(tv.Type, "Privilege"):
+			return spoon.Privilege(s), nil
+		}
+		return s, nil
+	case constant.Bool:
+		return constant.BoolVal(tv.Value), nil
+	case constant.Int:
+		if i, ok := constant.Int64Val(tv.Value); ok {
+			return i, nil
+		}
+	}
+
+	return nil, sp.errorf(expr, "unsupported constant %s", tv.Value)
+}
+
+func (sp *Parser) evalCompositeLit(lit *ast.CompositeLit, t types.Type) (interface{}, error) {
+	switch {
+	case isSpoonType(t, "KeyPart"):
+		var kp spoon.KeyPart
+		for i, elt := range lit.Elts {
+			field := []string{"ColumnName", "IsOrderDesc"}[i]
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				field = kv.Key.(*ast.Ident).Name
+				elt = kv.Value
+			}
+			v, err := sp.eval(elt)
+			if err != nil {
+				return nil, err
+			}
+			switch field {
+			case "ColumnName":
+				switch name := v.(type) {
+				case string:
+					kp.ColumnName = name
+				case spoon.ColumnName:
+					kp.ColumnName = string(name)
+				default:
+					return nil, sp.errorf(elt, "ColumnName must be string")
+				}
+			case "IsOrderDesc":
+				desc, ok := v.(bool)
+				if !ok {
+					return nil, sp.errorf(elt, "IsOrderDesc must be bool")
+				}
+				kp.IsOrderDesc = desc
+			}
+		}
+		return kp, nil
+	case isSpoonType(t, "Indexes"):
+		indexes := make(spoon.Indexes, 0, len(lit.Elts))
+		for _, elt := range lit.Elts {
+			v, err := sp.eval(elt)
+			if err != nil {
+				return nil, err
+			}
+			idx, ok := v.(*spoon.Index)
+			if !ok {
+				return nil, sp.errorf(elt, "element of Indexes must be created by AddIndex or AddUniqueIndex")
+			}
+			indexes = append(indexes, idx)
+		}
+		return indexes, nil
+	case isSpoonType(t, "Grants"):
+		grants := make(spoon.Grants, 0, len(lit.Elts))
+		for _, elt := range lit.Elts {
+			v, err := sp.eval(elt)
+			if err != nil {
+				return nil, err
+			}
+			g, ok := v.(*spoon.Grant)
+			if !ok {
+				return nil, sp.errorf(elt, "element of Grants must be created by AddGrant or AddColumnGrant")
+			}
+			grants = append(grants, g)
+		}
+		return grants, nil
+	}
+
+	return nil, sp.errorf(lit, "unsupported composite literal of %s", t)
+}
+
+func (sp *Parser) evalCall(call *ast.CallExpr) (interface{}, error) {
+	if call.Ellipsis.IsValid() {
+		return nil, sp.errorf(call, "variadic argument is not supported")
+	}
+
+	// ColumnName("ID").Asc() and UserColumns.ID.Desc()
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && isSpoonType(sp.info.Types[sel.X].Type, "ColumnName") && len(call.Args) == 0 {
+		v, err := sp.eval(sel.X)
+		if err != nil {
+			return nil, err
+		}
+		switch sel.Sel.Name {
+		case "Asc":
+			return v.(spoon.ColumnName).Asc(), nil
+		case "Desc":
+			return v.(spoon.ColumnName).Desc(), nil
+		}
+	}
+
+	fn := typeutil.StaticCallee(sp.info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != spoonPkgPath {
+		return nil, sp.errorf(call, "unsupported call %s", types.ExprString(call.Fun))
+	}
+
+	args := make([]interface{}, 0, len(call.Args))
+	for _, arg := range call.Args {
+		v, err := sp.eval(arg)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+
+	var (
+		v        interface{}
+		keyParts []ast.Expr
+	)
+	switch fn.Name() {
+	case "AddPrimaryKey":
+		kps, err := sp.keyParts(call, args)
+		if err != nil {
+			return nil, err
+		}
+		v, keyParts = spoon.AddPrimaryKey(kps...), call.Args
+	case "AddPrimaryKeyWithInterleave":
+		if len(args) < 1 {
+			break
+		}
+		parent, ok := args[0].(string)
+		if !ok {
+			break
+		}
+		kps, err := sp.keyParts(call, args[1:])
+		if err != nil {
+			return nil, err
+		}
+		v, keyParts = spoon.AddPrimaryKeyWithInterleave(parent, kps...), call.Args[1:]
+	case "AddIndex", "AddUniqueIndex":
+		if len(args) < 3 {
+			break
+		}
+		name, ok1 := args[0].(string)
+		tableName, ok2 := args[1].(string)
+		nullFiltered, ok3 := args[2].(bool)
+		if !ok1 || !ok2 || !ok3 {
+			break
+		}
+		kps, err := sp.keyParts(call, args[3:])
+		if err != nil {
+			return nil, err
+		}
+		if fn.Name() == "AddUniqueIndex" {
+			v = spoon.AddUniqueIndex(name, tableName, nullFiltered, kps...)
+		} else {
+			v = spoon.AddIndex(name, tableName, nullFiltered, kps...)
+		}
+		keyParts = call.Args[3:]
+	case "AddGrant", "AddColumnGrant":
+		if len(args) < 3 {
+			break
+		}
+		roleName, ok1 := args[0].(string)
+		privilege, ok2 := args[1].(spoon.Privilege)
+		tableName, ok3 := args[2].(string)
+		if !ok1 || !ok2 || !ok3 {
+			break
+		}
+		columns := make([]string, 0, len(args)-3)
+		for _, a := range args[3:] {
+			c, ok := a.(string)
+			if !ok {
+				return nil, sp.errorf(call, "column of %s must be string", fn.Name())
+			}
+			columns = append(columns, c)
+		}
+		if fn.Name() == "AddColumnGrant" {
+			v = spoon.AddColumnGrant(roleName, privilege, tableName, columns...)
+		} else {
+			v = spoon.AddGrant(roleName, privilege, tableName)
+		}
+	default:
+		return nil, sp.errorf(call, "unsupported call %s", types.ExprString(call.Fun))
+	}
+	if v == nil {
+		return nil, sp.errorf(call, "unsupported arguments of %s", fn.Name())
+	}
+	sp.calls[v] = &spoonCall{call: call, keyParts: keyParts}
+
+	return v, nil
+}
+
+func (sp *Parser) keyParts(call *ast.CallExpr, args []interface{}) ([]spoon.KeyPart, error) {
+	kps := make([]spoon.KeyPart, 0, len(args))
+	for _, a := range args {
+		kp, ok := a.(spoon.KeyPart)
+		if !ok {
+			return nil, sp.errorf(call, "key part must be KeyPart")
+		}
+		kps = append(kps, kp)
+	}
+
+	return kps, nil
+}
+
+// isSpoonType reports whether t is the named type of spoon package.
+func isSpoonType(t types.Type, name string) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == spoonPkgPath && obj.Name() == name
+}
@@ -0,0 +1,315 @@
+// Package source parses the Entity from the type-checked source of the package without reflection.
+package source
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+
+	"github.com/pi9min/spoon"
+	"github.com/pi9min/spoon/internal/bridge"
+	"github.com/pkg/errors"
+)
+
+const spoonPkgPath = "github.com/pi9min/spoon"
+
+// Error is the error at the position of the source.
+type Error struct {
+	Pos      token.Pos
+	Position token.Position
+	Err      error
+}
+
+func (e *Error) Error() string {
+	return e.Position.String() + ": " + e.Err.Error()
+}
+
+// Parser parses Entity from the type-checked source of the package.
+type Parser struct {
+	parser bridge.Parser
+	fset   *token.FileSet
+	pkg    *types.Package
+	info   *types.Info
+	funcs  map[*types.Func]*ast.FuncDecl
+	// calls maps *PrimaryKey, *Index and *Grant to the call of the spoon function that created it.
+	calls map[interface{}]*spoonCall
+	// Report is called with the error of the field instead of returning it if it is not nil,
+	// and the field is kept as the column without the tag.
+	Report func(*Error)
+}
+
+// spoonCall is the call of the spoon function and its arguments of KeyPart.
+type spoonCall struct {
+	call     *ast.CallExpr
+	keyParts []ast.Expr
+}
+
+// NewParser returns Parser of the package that parses the fields by p.
+func NewParser(p bridge.Parser, fset *token.FileSet, pkg *types.Package, info *types.Info, files []*ast.File) *Parser {
+	funcs := make(map[*types.Func]*ast.FuncDecl)
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			if fn, ok := info.Defs[fd.Name].(*types.Func); ok {
+				funcs[fn] = fd
+			}
+		}
+	}
+
+	return &Parser{
+		parser: p,
+		fset:   fset,
+		pkg:    pkg,
+		info:   info,
+		funcs:  funcs,
+		calls:  make(map[interface{}]*spoonCall),
+	}
+}
+
+func (sp *Parser) errorf(node ast.Node, format string, args ...interface{}) error {
+	return sp.errorAt(node.Pos(), errors.Errorf(format, args...))
+}
+
+func (sp *Parser) errorAt(pos token.Pos, err error) *Error {
+	return &Error{Pos: pos, Position: sp.fset.Position(pos), Err: err}
+}
+
+// Entities returns the struct types that have the TableName method in order of the name.
+func (sp *Parser) Entities() []*types.TypeName {
+	var tns []*types.TypeName
+	scope := sp.pkg.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			continue
+		}
+		if _, ok := tn.Type().Underlying().(*types.Struct); !ok {
+			continue
+		}
+		if sp.method(tn, "TableName") != nil {
+			tns = append(tns, tn)
+		}
+	}
+
+	return tns
+}
+
+// method returns the method of the type or its pointer type that has no parameter and one result, or nil.
+func (sp *Parser) method(tn *types.TypeName, name string) *types.Func {
+	obj, _, _ := types.LookupFieldOrMethod(tn.Type(), true, sp.pkg, name)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return nil
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return nil
+	}
+
+	return fn
+}
+
+// declaration is the Entity whose methods return the values evaluated from the source.
+type declaration struct {
+	tableName     string
+	primaryKey    *spoon.PrimaryKey
+	indexes       spoon.Indexes
+	grants        spoon.Grants
+	localityGroup string
+	renamedFrom   string
+}
+
+func (d *declaration) TableName() string {
+	return d.tableName
+}
+
+func (d *declaration) PrimaryKey() *spoon.PrimaryKey {
+	return d.primaryKey
+}
+
+func (d *declaration) Indexes() spoon.Indexes {
+	return d.indexes
+}
+
+func (d *declaration) Grants() spoon.Grants {
+	return d.grants
+}
+
+func (d *declaration) LocalityGroup() string {
+	return d.localityGroup
+}
+
+func (d *declaration) PreviousTableName() string {
+	return d.renamedFrom
+}
+
+// schemaDeclaration is the declaration of the Entity that has the SchemaName method.
+type schemaDeclaration struct {
+	*declaration
+	schemaName string
+}
+
+func (d *schemaDeclaration) SchemaName() string {
+	return d.schemaName
+}
+
+// declare evaluates the methods of the Entity. The errors of all methods are returned in order.
+func (sp *Parser) declare(tn *types.TypeName) (spoon.TableBehavior, *declaration, []error) {
+	d := &declaration{}
+	errs := []error{
+		sp.evalMethod(tn, "TableName", &d.tableName),
+		sp.evalMethod(tn, "PrimaryKey", &d.primaryKey),
+		sp.evalMethod(tn, "Indexes", &d.indexes),
+		sp.evalMethod(tn, "Grants", &d.grants),
+		sp.evalMethod(tn, "LocalityGroup", &d.localityGroup),
+		sp.evalMethod(tn, "PreviousTableName", &d.renamedFrom),
+	}
+	if sp.method(tn, "SchemaName") != nil {
+		sd := &schemaDeclaration{declaration: d}
+		errs = append(errs, sp.evalMethod(tn, "SchemaName", &sd.schemaName))
+		return sd, d, errs
+	}
+
+	return d, d, errs
+}
+
+// Parse returns the table of the Entity.
+func (sp *Parser) Parse(tn *types.TypeName) (*spoon.Table, error) {
+	columns, err := sp.parseStructType(tn.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	tb, _, errs := sp.declare(tn)
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sp.newTable(tb, columns)
+}
+
+func (sp *Parser) newTable(tb spoon.TableBehavior, columns []*spoon.Column) (*spoon.Table, error) {
+	cols := make([]any, 0, len(columns))
+	for _, c := range columns {
+		cols = append(cols, c)
+	}
+	t, err := sp.parser.NewTable(tb, cols)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.(*spoon.Table), nil
+}
+
+// parseStructType parses the fields of the struct type in the same way as spoon parses the struct by reflection.
+func (sp *Parser) parseStructType(t types.Type) ([]*spoon.Column, error) {
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil, errors.Errorf("%s is not struct", t)
+	}
+
+	columns := make([]*spoon.Column, 0, st.NumFields())
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if ptr, ok := field.Type().Underlying().(*types.Pointer); ok {
+			if _, ok := ptr.Elem().Underlying().(*types.Struct); ok {
+				cols, err := sp.parseStructType(ptr.Elem())
+				if err != nil {
+					return nil, err
+				}
+				columns = append(columns, cols...)
+				continue
+			}
+		}
+
+		col, err := sp.parseField(field, reflect.StructTag(st.Tag(i)).Get(sp.parser.TagPrefix()))
+		if err != nil {
+			if sp.Report == nil {
+				return nil, sp.errorAt(field.Pos(), err)
+			}
+			sp.Report(sp.errorAt(field.Pos(), err))
+			if col, err = sp.parseField(field, ""); err != nil {
+				return nil, sp.errorAt(field.Pos(), err)
+			}
+		}
+		if col != nil {
+			columns = append(columns, col)
+		}
+	}
+
+	return columns, nil
+}
+
+// parseField returns the column of the field, or nil if the field is ignored.
+func (sp *Parser) parseField(field *types.Var, tag string) (*spoon.Column, error) {
+	col, err := sp.parser.ParseField(field.Name(), tag, goType(field.Type()))
+	if err != nil || col == nil {
+		return nil, err
+	}
+	if sp.Report != nil {
+		if err := sp.parser.LintField(col, tag); err != nil {
+			return nil, err
+		}
+	}
+
+	return col.(*spoon.Column), nil
+}
+
+// basicKinds maps the kind of the basic type to the kind of reflect.
+var basicKinds = map[types.BasicKind]reflect.Kind{
+	types.Bool:          reflect.Bool,
+	types.Int:           reflect.Int,
+	types.Int8:          reflect.Int8,
+	types.Int16:         reflect.Int16,
+	types.Int32:         reflect.Int32,
+	types.Int64:         reflect.Int64,
+	types.Uint:          reflect.Uint,
+	types.Uint8:         reflect.Uint8,
+	types.Uint16:        reflect.Uint16,
+	types.Uint32:        reflect.Uint32,
+	types.Uint64:        reflect.Uint64,
+	types.Uintptr:       reflect.Uintptr,
+	types.Float32:       reflect.Float32,
+	types.Float64:       reflect.Float64,
+	types.Complex64:     reflect.Complex64,
+	types.Complex128:    reflect.Complex128,
+	types.String:        reflect.String,
+	types.UnsafePointer: reflect.UnsafePointer,
+}
+
+// goType describes the type in the same way as reflect, so the type of the column is derived from it by spoon.
+func goType(t types.Type) *bridge.Type {
+	gt := &bridge.Type{}
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		gt.Name = named.Obj().Name()
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		gt.Kind = basicKinds[u.Kind()]
+	case *types.Pointer:
+		gt.Kind, gt.Elem = reflect.Ptr, goType(u.Elem())
+	case *types.Slice:
+		gt.Kind, gt.Elem = reflect.Slice, goType(u.Elem())
+	case *types.Array:
+		gt.Kind = reflect.Array
+	case *types.Map:
+		gt.Kind = reflect.Map
+	case *types.Chan:
+		gt.Kind = reflect.Chan
+	case *types.Signature:
+		gt.Kind = reflect.Func
+	case *types.Interface:
+		gt.Kind = reflect.Interface
+	case *types.Struct:
+		gt.Kind = reflect.Struct
+	}
+
+	return gt
+}
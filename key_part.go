@@ -1,7 +1,21 @@
 package spoon
 
+// ColumnName is the name of the column referenced by KeyPart.
+// The column names generated by `spoongen` are typed as ColumnName, and Asc and Desc convert them to KeyPart.
+type ColumnName string
+
 // KeyPart holds the columns and the order of arrangement that constitute the key to the index.
 type KeyPart struct {
 	ColumnName  string
 	IsOrderDesc bool
 }
+
+// Asc returns KeyPart in ascending order.
+func (c ColumnName) Asc() KeyPart {
+	return KeyPart{ColumnName: string(c)}
+}
+
+// Desc returns KeyPart in descending order.
+func (c ColumnName) Desc() KeyPart {
+	return KeyPart{ColumnName: string(c), IsOrderDesc: true}
+}
@@ -1,11 +1,31 @@
 package spoon
 
+import (
+	"strings"
+)
+
 // Quote quotes the string.
+// The schema-qualified name such as `sales.Orders` is quoted per part.
 func Quote(unquoted string) string {
-	return "`" + unquoted + "`"
+	parts := strings.Split(unquoted, ".")
+	for i := range parts {
+		parts[i] = "`" + parts[i] + "`"
+	}
+
+	return strings.Join(parts, ".")
 }
 
 // Semicolon adds a semicolon at the end.
 func Semicolon(schema string) string {
 	return schema + ";"
 }
+
+// qualify qualifies the name with the schema name.
+// The name already qualified and the name in the default schema are returned as is.
+func qualify(schemaName, name string) string {
+	if schemaName == "" || strings.Contains(name, ".") {
+		return name
+	}
+
+	return schemaName + "." + name
+}
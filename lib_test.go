@@ -22,6 +22,13 @@ func TestQuote(t *testing.T) {
 			},
 			want: "`abc123`",
 		},
+		{
+			name: "schema qualified",
+			args: args{
+				unquoted: "sales.Orders",
+			},
+			want: "`sales`.`Orders`",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,74 @@
+package spoon
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Storage is the storage type of the locality group.
+type Storage string
+
+const (
+	StorageSSD Storage = "ssd"
+	StorageHDD Storage = "hdd"
+)
+
+// LocalityGroupBehavior defines the interface that places an Entity in the locality group.
+type LocalityGroupBehavior interface {
+	LocalityGroup() string
+}
+
+// LocalityGroups are alias of locality group slices.
+type LocalityGroups []*LocalityGroup
+
+// LocalityGroup holds the necessary information to construct locality group.
+type LocalityGroup struct {
+	name                  string
+	storage               Storage
+	ssdToHddSpillTimespan string
+}
+
+// AddLocalityGroup creates LocalityGroup.
+func AddLocalityGroup(name string, storage Storage) *LocalityGroup {
+	return &LocalityGroup{
+		name:    name,
+		storage: storage,
+	}
+}
+
+// AddLocalityGroupWithSpill creates LocalityGroup on SSD which moves data older than the timespan (e.g. `10d`) to HDD.
+func AddLocalityGroupWithSpill(name string, ssdToHddSpillTimespan string) *LocalityGroup {
+	return &LocalityGroup{
+		name:                  name,
+		storage:               StorageSSD,
+		ssdToHddSpillTimespan: ssdToHddSpillTimespan,
+	}
+}
+
+// CreateLocalityGroupSchema return `CREATE LOCALITY GROUP` schema.
+func (lg *LocalityGroup) CreateLocalityGroupSchema() string {
+	return fmt.Sprintf("CREATE LOCALITY GROUP %s OPTIONS (%s)", Quote(lg.name), lg.options())
+}
+
+// AlterLocalityGroupSchema return `ALTER LOCALITY GROUP` schema.
+func (lg *LocalityGroup) AlterLocalityGroupSchema() string {
+	return fmt.Sprintf("ALTER LOCALITY GROUP %s SET OPTIONS (%s)", Quote(lg.name), lg.options())
+}
+
+// DropLocalityGroupSchema return `DROP LOCALITY GROUP` schema.
+func (lg *LocalityGroup) DropLocalityGroupSchema() string {
+	return fmt.Sprintf("DROP LOCALITY GROUP %s", Quote(lg.name))
+}
+
+func (lg *LocalityGroup) options() string {
+	opts := []string{fmt.Sprintf("storage = '%s'", lg.storage)}
+	if lg.ssdToHddSpillTimespan != "" {
+		opts = append(opts, fmt.Sprintf("ssd_to_hdd_spill_timespan = '%s'", lg.ssdToHddSpillTimespan))
+	}
+
+	return strings.Join(opts, ", ")
+}
+
+func localityGroupOption(name string) string {
+	return fmt.Sprintf("OPTIONS (locality_group = '%s')", name)
+}
@@ -0,0 +1,111 @@
+package spoon_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+type Test8 struct {
+	ID   int64
+	Blob []byte `db:"locality_group=cold"`
+}
+
+func (t8 *Test8) TableName() string {
+	return "Test8"
+}
+
+func (t8 *Test8) Indexes() spoon.Indexes {
+	return spoon.Indexes{}
+}
+
+func (t8 *Test8) PrimaryKey() *spoon.PrimaryKey {
+	return spoon.AddPrimaryKey(spoon.KeyPart{ColumnName: "ID"})
+}
+
+func (t8 *Test8) LocalityGroup() string {
+	return "spill"
+}
+
+func TestAddLocalityGroup(t *testing.T) {
+	tests := []struct {
+		name         string
+		lg           *spoon.LocalityGroup
+		expectCreate string
+		expectAlter  string
+		expectDrop   string
+	}{
+		{
+			name:         "1 hdd",
+			lg:           spoon.AddLocalityGroup("cold", spoon.StorageHDD),
+			expectCreate: "CREATE LOCALITY GROUP `cold` OPTIONS (storage = 'hdd')",
+			expectAlter:  "ALTER LOCALITY GROUP `cold` SET OPTIONS (storage = 'hdd')",
+			expectDrop:   "DROP LOCALITY GROUP `cold`",
+		},
+		{
+			name:         "2 ssd with spill",
+			lg:           spoon.AddLocalityGroupWithSpill("spill", "10d"),
+			expectCreate: "CREATE LOCALITY GROUP `spill` OPTIONS (storage = 'ssd', ssd_to_hdd_spill_timespan = '10d')",
+			expectAlter:  "ALTER LOCALITY GROUP `spill` SET OPTIONS (storage = 'ssd', ssd_to_hdd_spill_timespan = '10d')",
+			expectDrop:   "DROP LOCALITY GROUP `spill`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expectCreate, tt.lg.CreateLocalityGroupSchema()); diff != "" {
+				t.Errorf("CreateLocalityGroupSchema Diff:\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.expectAlter, tt.lg.AlterLocalityGroupSchema()); diff != "" {
+				t.Errorf("AlterLocalityGroupSchema Diff:\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.expectDrop, tt.lg.DropLocalityGroupSchema()); diff != "" {
+				t.Errorf("DropLocalityGroupSchema Diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateSchema_LocalityGroup(t *testing.T) {
+	cli, err := spoon.New(spoon.WithLocalityGroups(
+		spoon.AddLocalityGroup("cold", spoon.StorageHDD),
+		spoon.AddLocalityGroupWithSpill("spill", "10d"),
+	))
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	actual, err := cli.GenerateSchema([]spoon.EntityBehavior{&Test8{}})
+	if err != nil {
+		t.Fatalf("error generate schema %#v", err)
+	}
+	expect := []string{
+		"CREATE LOCALITY GROUP `cold` OPTIONS (storage = 'hdd')",
+		"CREATE LOCALITY GROUP `spill` OPTIONS (storage = 'ssd', ssd_to_hdd_spill_timespan = '10d')",
+		"CREATE TABLE `Test8` (\n    `ID` INT64 NOT NULL,\n    `Blob` BYTES(MAX) NOT NULL OPTIONS (locality_group = 'cold'),\n) PRIMARY KEY (`ID`), OPTIONS (locality_group = 'spill')",
+	}
+	if diff := cmp.Diff(expect, actual); diff != "" {
+		t.Errorf("GenerateSchema Diff:\n%s", diff)
+	}
+
+	alters, err := cli.GenerateAlterLocalityGroups(&Test8{})
+	if err != nil {
+		t.Fatalf("error generate alter locality groups %#v", err)
+	}
+	expectAlters := []string{
+		"ALTER TABLE `Test8` SET OPTIONS (locality_group = 'spill')",
+		"ALTER TABLE `Test8` ALTER COLUMN `Blob` SET OPTIONS (locality_group = 'cold')",
+	}
+	if diff := cmp.Diff(expectAlters, alters); diff != "" {
+		t.Errorf("GenerateAlterLocalityGroups Diff:\n%s", diff)
+	}
+
+	noLGCli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+	if _, err := noLGCli.GenerateSchema([]spoon.EntityBehavior{&Test8{}}); err == nil {
+		t.Errorf("expect error undefined locality group")
+	}
+}
@@ -0,0 +1,225 @@
+package spoon
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoChange is returned when the schema has not changed since the last migration.
+var ErrNoChange = errors.New("no schema change")
+
+// minVersionWidth is the number of digits of the version written to the empty migration directory.
+const minVersionWidth = 6
+
+var (
+	migrationFileRe = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+	migrationNameRe = regexp.MustCompile(`^\w+$`)
+)
+
+// Migration is the version of the schema in the migration directory.
+type Migration struct {
+	Version int
+	Name    string
+	// UpFile is the path of the file that migrates the previous version to this version.
+	UpFile string
+	// DownFile is the path of the file that migrates this version back to the previous version,
+	// or empty string if it does not exist.
+	DownFile string
+	// Irreversibles are the changes that the down file can not revert without losing data.
+	// It is only set to the migration written by WriteNext.
+	Irreversibles Irreversibles
+}
+
+// MigrationDir is the directory of the versioned migration files such as `000001_create_singers.up.sql`
+// and `000001_create_singers.down.sql`, which is the layout of golang-migrate and the compatible tools.
+type MigrationDir struct {
+	path       string
+	migrations []*Migration
+	width      int
+}
+
+// OpenMigrationDir reads the migration files in the directory.
+// The other files are ignored. It returns an error if the versions have a gap or a duplicate,
+// or if a down file has no up file.
+func OpenMigrationDir(path string) (*MigrationDir, error) {
+	files, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &MigrationDir{path: path, width: minVersionWidth}
+	byVersion := make(map[int]*Migration)
+	var downs []*Migration
+	for _, fi := range files {
+		m := migrationFileRe.FindStringSubmatch(fi.Name())
+		if fi.IsDir() || m == nil {
+			continue
+		}
+		version, err := strconv.Atoi(m[1])
+		if err != nil {
+			return nil, errors.Errorf("%s: invalid version", fi.Name())
+		}
+		if len(m[1]) > d.width {
+			d.width = len(m[1])
+		}
+
+		file := filepath.Join(path, fi.Name())
+		if m[3] == "down" {
+			downs = append(downs, &Migration{Version: version, Name: m[2], DownFile: file})
+			continue
+		}
+		if dup, ok := byVersion[version]; ok {
+			return nil, errors.Errorf("%s: duplicate version %d of %s", fi.Name(), version, filepath.Base(dup.UpFile))
+		}
+		mig := &Migration{Version: version, Name: m[2], UpFile: file}
+		byVersion[version] = mig
+		d.migrations = append(d.migrations, mig)
+	}
+
+	for _, down := range downs {
+		mig, ok := byVersion[down.Version]
+		switch {
+		case !ok || mig.Name != down.Name:
+			return nil, errors.Errorf("%s: up file does not exist", filepath.Base(down.DownFile))
+		case mig.DownFile != "":
+			return nil, errors.Errorf("%s: duplicate version %d of %s", filepath.Base(down.DownFile), down.Version, filepath.Base(mig.DownFile))
+		}
+		mig.DownFile = down.DownFile
+	}
+
+	sort.Slice(d.migrations, func(i, j int) bool { return d.migrations[i].Version < d.migrations[j].Version })
+	for i := 1; i < len(d.migrations); i++ {
+		if prev, mig := d.migrations[i-1], d.migrations[i]; mig.Version != prev.Version+1 {
+			return nil, errors.Errorf("%s: gap after version %d", filepath.Base(mig.UpFile), prev.Version)
+		}
+	}
+
+	return d, nil
+}
+
+// Migrations returns the migrations in order of the version.
+func (d *MigrationDir) Migrations() []*Migration {
+	return append([]*Migration(nil), d.migrations...)
+}
+
+// Schema returns the schema that the up files of all versions build.
+func (d *MigrationDir) Schema() (*Schema, error) {
+	st := newDDLState()
+	for _, mig := range d.migrations {
+		ddl, err := os.ReadFile(mig.UpFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := st.apply(string(ddl)); err != nil {
+			return nil, errors.Wrap(err, filepath.Base(mig.UpFile))
+		}
+	}
+
+	return st.schema()
+}
+
+// WriteNext writes the migration of the next version that migrates the schema of the directory to s.
+// It returns ErrNoChange if nothing has changed. See Schema.Diff and Schema.ReverseDiff for the statements.
+// The irreversible changes are written as the comment at the beginning of the down file.
+func (d *MigrationDir) WriteNext(s *Schema, name string, opts ...FormatOption) (*Migration, error) {
+	return d.writeNext(s, name, newFormat(opts))
+}
+
+func (d *MigrationDir) writeNext(s *Schema, name string, f *format) (*Migration, error) {
+	if !migrationNameRe.MatchString(name) {
+		return nil, errors.Errorf("invalid migration name %q", name)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	old, err := d.Schema()
+	if err != nil {
+		return nil, err
+	}
+	ss, err := s.diff(old, f)
+	if err != nil {
+		return nil, err
+	}
+	if len(ss) == 0 {
+		return nil, ErrNoChange
+	}
+	downs, is, err := s.reverseDiff(old, f)
+	if err != nil {
+		return nil, err
+	}
+
+	version := 1
+	if len(d.migrations) != 0 {
+		version = d.migrations[len(d.migrations)-1].Version + 1
+	}
+	prefix := filepath.Join(d.path, fmt.Sprintf("%0*d_%s", d.width, version, name))
+	mig := &Migration{Version: version, Name: name, UpFile: prefix + ".up.sql", DownFile: prefix + ".down.sql", Irreversibles: is}
+
+	var up, down bytes.Buffer
+	if err := writeStatements(&up, ss, f); err != nil {
+		return nil, err
+	}
+	downFormat := *f
+	if len(is) != 0 {
+		header := "IRREVERSIBLE: the data lost by the up migration is not restored.\n" + strings.TrimRight(is.String(), "\n")
+		if f.header != "" {
+			header = f.header + "\n\n" + header
+		}
+		downFormat.header = header
+	}
+	if err := writeStatements(&down, downs, &downFormat); err != nil {
+		return nil, err
+	}
+
+	if err := writeNewFile(mig.UpFile, up.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := writeNewFile(mig.DownFile, down.Bytes()); err != nil {
+		os.Remove(mig.UpFile)
+		return nil, err
+	}
+	d.migrations = append(d.migrations, mig)
+
+	return mig, nil
+}
+
+// writeNewFile writes the file that must not exist.
+func writeNewFile(path string, b []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(b); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+
+	return file.Close()
+}
+
+// WriteMigration writes the migration of the next version to the directory,
+// which migrates the schema of the directory to the whole schema of the specified Entity.
+// See MigrationDir.WriteNext for the files.
+func (c *Client) WriteMigration(dir, name string, ebs []EntityBehavior, opts ...FormatOption) (*Migration, error) {
+	s, err := c.BuildSchema(ebs)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := OpenMigrationDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.writeNext(s, name, c.format(opts...))
+}
@@ -0,0 +1,128 @@
+package spoon_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+func TestClient_WriteMigration(t *testing.T) {
+	dir, err := os.MkdirTemp("", "migrations")
+	if err != nil {
+		t.Fatalf("error create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cli, err := spoon.New(spoon.WithSequences(spoon.AddSequence("Test3Seq")))
+	if err != nil {
+		t.Fatalf("error new client: %v", err)
+	}
+
+	m, err := cli.WriteMigration(dir, "create_test3", []spoon.EntityBehavior{&Test3{}})
+	if err != nil {
+		t.Fatalf("error WriteMigration: %v", err)
+	}
+	if _, err := cli.WriteMigration(dir, "nothing", []spoon.EntityBehavior{&Test3{}}); err != spoon.ErrNoChange {
+		t.Errorf("expect ErrNoChange, but %v", err)
+	}
+	m2, err := cli.WriteMigration(dir, "add_test1", []spoon.EntityBehavior{&Test3{}, Test1{}})
+	if err != nil {
+		t.Fatalf("error WriteMigration: %v", err)
+	}
+
+	expect := []*spoon.Migration{
+		{Version: 1, Name: "create_test3", UpFile: filepath.Join(dir, "000001_create_test3.up.sql"), DownFile: filepath.Join(dir, "000001_create_test3.down.sql")},
+		{Version: 2, Name: "add_test1", UpFile: filepath.Join(dir, "000002_add_test1.up.sql"), DownFile: filepath.Join(dir, "000002_add_test1.down.sql")},
+	}
+	if diff := cmp.Diff(expect, []*spoon.Migration{m, m2}); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+
+	up, err := os.ReadFile(m.UpFile)
+	if err != nil {
+		t.Fatalf("error read up file: %v", err)
+	}
+	expectUp := "CREATE SEQUENCE `Test3Seq` OPTIONS (sequence_kind = 'bit_reversed_positive');\n\n" +
+		"CREATE TABLE `Test3` (\n    `ID` INT64 NOT NULL DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE `Test3Seq`)),\n    `Name` STRING(MAX) NOT NULL,\n) PRIMARY KEY (`ID`);\n\n" +
+		"CREATE INDEX `Test3ByName` ON `Test3` (`Name`);\n"
+	if diff := cmp.Diff(expectUp, string(up)); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+
+	m3, err := cli.WriteMigration(dir, "drop_test1", []spoon.EntityBehavior{&Test3{}})
+	if err != nil {
+		t.Fatalf("error WriteMigration: %v", err)
+	}
+	expectIrreversibles := spoon.Irreversibles{{Object: "TABLE Test1", Reason: "the rows of the dropped table are not restored"}}
+	if diff := cmp.Diff(expectIrreversibles, m3.Irreversibles); diff != "" {
+		t.Errorf("Irreversibles Diff:\n%s", diff)
+	}
+	down, err := os.ReadFile(m3.DownFile)
+	if err != nil {
+		t.Fatalf("error read down file: %v", err)
+	}
+	expectDown := "-- IRREVERSIBLE: the data lost by the up migration is not restored.\n" +
+		"-- TABLE Test1: the rows of the dropped table are not restored\n\n" +
+		"CREATE TABLE `Test1` (\n    `ID` INT64 NOT NULL,\n    `Name` STRING(MAX) NOT NULL,\n    `CreatedAt` TIMESTAMP NOT NULL,\n    `UpdatedAt` TIMESTAMP NOT NULL,\n) PRIMARY KEY (`ID`);\n\n" +
+		"CREATE INDEX `Test1ByCreatedAtDesc` ON `Test1` (`CreatedAt` DESC);\n"
+	if diff := cmp.Diff(expectDown, string(down)); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+
+	d, err := spoon.OpenMigrationDir(dir)
+	if err != nil {
+		t.Fatalf("error OpenMigrationDir: %v", err)
+	}
+	expect = append(expect, &spoon.Migration{Version: 3, Name: "drop_test1", UpFile: m3.UpFile, DownFile: m3.DownFile})
+	if diff := cmp.Diff(expect, d.Migrations()); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+}
+
+func TestOpenMigrationDir_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  []string
+		expect string
+	}{
+		{
+			name:   "gap",
+			files:  []string{"000001_a.up.sql", "000003_c.up.sql"},
+			expect: "000003_c.up.sql: gap after version 1",
+		},
+		{
+			name:   "duplicate",
+			files:  []string{"000001_a.up.sql", "000002_b.up.sql", "02_c.up.sql"},
+			expect: "02_c.up.sql: duplicate version 2 of 000002_b.up.sql",
+		},
+		{
+			name:   "down without up",
+			files:  []string{"000001_a.up.sql", "000001_b.down.sql"},
+			expect: "000001_b.down.sql: up file does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := os.MkdirTemp("", "migrations")
+			if err != nil {
+				t.Fatalf("error create temp dir: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			for _, name := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+					t.Fatalf("error write %s: %v", name, err)
+				}
+			}
+
+			_, err = spoon.OpenMigrationDir(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.expect) {
+				t.Errorf("expect error %q, but %v", tt.expect, err)
+			}
+		})
+	}
+}
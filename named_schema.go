@@ -0,0 +1,53 @@
+package spoon
+
+import (
+	"fmt"
+)
+
+// NamedSchemas are alias of named schema slices.
+type NamedSchemas []*NamedSchema
+
+// NamedSchema holds the necessary information to construct named schema.
+type NamedSchema struct {
+	name string
+}
+
+// AddNamedSchema creates NamedSchema.
+func AddNamedSchema(name string) *NamedSchema {
+	return &NamedSchema{
+		name: name,
+	}
+}
+
+// CreateNamedSchemaSchema return `CREATE SCHEMA` schema.
+func (s *NamedSchema) CreateNamedSchemaSchema() string {
+	return s.createNamedSchemaSchema(defaultFormat())
+}
+
+func (s *NamedSchema) createNamedSchemaSchema(f *format) string {
+	return fmt.Sprintf("CREATE SCHEMA %s", f.quote(s.name))
+}
+
+// DropNamedSchemaSchema return `DROP SCHEMA` schema.
+func (s *NamedSchema) DropNamedSchemaSchema() string {
+	return s.dropNamedSchemaSchema(defaultFormat())
+}
+
+func (s *NamedSchema) dropNamedSchemaSchema(f *format) string {
+	return fmt.Sprintf("DROP SCHEMA %s", f.quote(s.name))
+}
+
+// namedSchemas returns the named schemas used by the tables in order of appearance.
+func namedSchemas(tables []*Table) NamedSchemas {
+	seen := make(map[string]bool)
+	var nss NamedSchemas
+	for _, t := range tables {
+		if t.schemaName == "" || seen[t.schemaName] {
+			continue
+		}
+		seen[t.schemaName] = true
+		nss = append(nss, AddNamedSchema(t.schemaName))
+	}
+
+	return nss
+}
@@ -0,0 +1,160 @@
+package spoon_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+type Test7 struct {
+	CustomerID int64
+	OrderID    int64
+	Amount     int64
+}
+
+func (t7 *Test7) SchemaName() string {
+	return "sales"
+}
+
+func (t7 *Test7) TableName() string {
+	return "Orders"
+}
+
+func (t7 *Test7) Indexes() spoon.Indexes {
+	return spoon.Indexes{
+		spoon.AddIndex("OrdersByAmount", "Orders", false, spoon.KeyPart{ColumnName: "Amount"}),
+	}
+}
+
+func (t7 *Test7) PrimaryKey() *spoon.PrimaryKey {
+	return spoon.AddPrimaryKeyWithInterleave("Customers", spoon.KeyPart{ColumnName: "CustomerID"}, spoon.KeyPart{ColumnName: "OrderID"})
+}
+
+type Test7Parent struct {
+	CustomerID int64 `db:"pk"`
+}
+
+func (t *Test7Parent) SchemaName() string {
+	return "sales"
+}
+
+func (t *Test7Parent) TableName() string {
+	return "Customers"
+}
+
+type Test7Item struct {
+	ID     int64 `db:"pk=1,interleave=Test1"`
+	ItemID int64 `db:"pk=2"`
+}
+
+func (t *Test7Item) SchemaName() string {
+	return "sales"
+}
+
+func (t *Test7Item) TableName() string {
+	return "Items"
+}
+
+func TestAddNamedSchema(t *testing.T) {
+	ns := spoon.AddNamedSchema("sales")
+	if diff := cmp.Diff("CREATE SCHEMA `sales`", ns.CreateNamedSchemaSchema()); diff != "" {
+		t.Errorf("CreateNamedSchemaSchema Diff:\n%s", diff)
+	}
+	if diff := cmp.Diff("DROP SCHEMA `sales`", ns.DropNamedSchemaSchema()); diff != "" {
+		t.Errorf("DropNamedSchemaSchema Diff:\n%s", diff)
+	}
+}
+
+func TestGenerateSchema_NamedSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []spoon.Option
+		entity []spoon.EntityBehavior
+		expect []string
+	}{
+		{
+			name:   "1 entity schema with parent",
+			entity: []spoon.EntityBehavior{&Test7{}, spoon.Entity(&Test7Parent{})},
+			expect: []string{
+				"CREATE SCHEMA `sales`",
+				"CREATE TABLE `sales`.`Customers` (\n    `CustomerID` INT64 NOT NULL,\n) PRIMARY KEY (`CustomerID`)",
+				"CREATE TABLE `sales`.`Orders` (\n    `CustomerID` INT64 NOT NULL,\n    `OrderID` INT64 NOT NULL,\n    `Amount` INT64 NOT NULL,\n) PRIMARY KEY (`CustomerID`, `OrderID`), INTERLEAVE IN PARENT `sales`.`Customers`",
+				"CREATE INDEX `sales`.`OrdersByAmount` ON `sales`.`Orders` (`Amount`)",
+			},
+		},
+		{
+			name:   "parent in default schema",
+			entity: []spoon.EntityBehavior{spoon.Entity(&Test7Item{}), Test1{}},
+			expect: []string{
+				"CREATE SCHEMA `sales`",
+				"CREATE TABLE `Test1` (\n    `ID` INT64 NOT NULL,\n    `Name` STRING(MAX) NOT NULL,\n    `CreatedAt` TIMESTAMP NOT NULL,\n    `UpdatedAt` TIMESTAMP NOT NULL,\n) PRIMARY KEY (`ID`)",
+				"CREATE TABLE `sales`.`Items` (\n    `ID` INT64 NOT NULL,\n    `ItemID` INT64 NOT NULL,\n) PRIMARY KEY (`ID`, `ItemID`), INTERLEAVE IN PARENT `Test1`",
+				"CREATE INDEX `Test1ByCreatedAtDesc` ON `Test1` (`CreatedAt` DESC)",
+			},
+		},
+		{
+			name:   "2 client schema",
+			opts:   []spoon.Option{spoon.WithSchemaName("app")},
+			entity: []spoon.EntityBehavior{&Test1{}},
+			expect: []string{
+				"CREATE SCHEMA `app`",
+				"CREATE TABLE `app`.`Test1` (\n    `ID` INT64 NOT NULL,\n    `Name` STRING(MAX) NOT NULL,\n    `CreatedAt` TIMESTAMP NOT NULL,\n    `UpdatedAt` TIMESTAMP NOT NULL,\n) PRIMARY KEY (`ID`)",
+				"CREATE INDEX `app`.`Test1ByCreatedAtDesc` ON `app`.`Test1` (`CreatedAt` DESC)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli, err := spoon.New(tt.opts...)
+			if err != nil {
+				t.Fatalf("error new Client")
+			}
+
+			actual, err := cli.GenerateSchema(tt.entity)
+			if err != nil {
+				t.Fatalf("error generate schema %#v", err)
+			}
+			if diff := cmp.Diff(tt.expect, actual); diff != "" {
+				t.Errorf("GenerateSchema Diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateCreateTable_NamedSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		entity spoon.TableBehavior
+		expect string
+	}{
+		{
+			name:   "1 parent in default schema",
+			entity: spoon.Entity(&Test7Item{}),
+			expect: "CREATE TABLE `sales`.`Items` (\n    `ID` INT64 NOT NULL,\n    `ItemID` INT64 NOT NULL,\n) PRIMARY KEY (`ID`, `ItemID`), INTERLEAVE IN PARENT `Test1`",
+		},
+		{
+			name:   "2 parent as declared",
+			entity: &Test7{},
+			expect: "CREATE TABLE `sales`.`Orders` (\n    `CustomerID` INT64 NOT NULL,\n    `OrderID` INT64 NOT NULL,\n    `Amount` INT64 NOT NULL,\n) PRIMARY KEY (`CustomerID`, `OrderID`), INTERLEAVE IN PARENT `Customers`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli, err := spoon.New()
+			if err != nil {
+				t.Fatalf("error new Client")
+			}
+
+			actual, err := cli.GenerateCreateTable(tt.entity)
+			if err != nil {
+				t.Fatalf("error GenerateCreateTable: %v", err)
+			}
+			if diff := cmp.Diff(tt.expect, actual); diff != "" {
+				t.Errorf("GenerateCreateTable Diff:\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,5 +1,9 @@
 package spoon
 
+import (
+	"github.com/pkg/errors"
+)
+
 type Option func(*optionParam) error
 
 func TagPrefix(tp string) Option {
@@ -15,3 +19,68 @@ func IgnoreTag(ig string) Option {
 		return nil
 	}
 }
+
+// WithSequences sets the sequences referenced by the `sequence` tag.
+func WithSequences(seqs ...*Sequence) Option {
+	return func(p *optionParam) error {
+		p.sequences = append(p.sequences, seqs...)
+		return nil
+	}
+}
+
+// WithDatabase sets the database name and the options output by `ALTER DATABASE`.
+func WithDatabase(name string, opts DatabaseOptions) Option {
+	return func(p *optionParam) error {
+		if name == "" {
+			return errors.New("database name is empty")
+		}
+		p.dbName = name
+		p.dbOptions = opts
+		return nil
+	}
+}
+
+// WithRoles sets the database roles granted by the Entity.
+func WithRoles(roles ...*Role) Option {
+	return func(p *optionParam) error {
+		p.roles = append(p.roles, roles...)
+		return nil
+	}
+}
+
+// WithSchemaName sets the named schema of the Entity that does not implement SchemaBehavior.
+func WithSchemaName(name string) Option {
+	return func(p *optionParam) error {
+		p.schemaName = name
+		return nil
+	}
+}
+
+// WithLocalityGroups sets the locality groups referenced by the Entity and the `locality_group` tag.
+func WithLocalityGroups(lgs ...*LocalityGroup) Option {
+	return func(p *optionParam) error {
+		p.localityGroups = append(p.localityGroups, lgs...)
+		return nil
+	}
+}
+
+// Idempotent makes the client output the `IF NOT EXISTS` and `IF EXISTS` variants
+// of `CREATE` and `DROP` statements of tables, indexes and sequences.
+// Pass IfNotExists(false) or IfExists(false) to a call to output the plain ones.
+func Idempotent() Option {
+	return func(p *optionParam) error {
+		p.formatOptions = append(p.formatOptions, IfNotExists(true), IfExists(true))
+		return nil
+	}
+}
+
+// WithDialect sets the dialect of the output DDL. Default is GoogleSQL.
+func WithDialect(d Dialect) Option {
+	return func(p *optionParam) error {
+		if err := d.validate(); err != nil {
+			return err
+		}
+		p.formatOptions = append(p.formatOptions, OutputDialect(d))
+		return nil
+	}
+}
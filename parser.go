@@ -4,29 +4,103 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 )
 
 // parser is
 type parser struct {
-	tagPrefix string
-	ignoreTag string
+	tagPrefix  string
+	ignoreTag  string
+	schemaName string
 }
 
-func newParser(tagPrefix, ignoreTag string) *parser {
+func newParser(tagPrefix, ignoreTag, schemaName string) *parser {
 	return &parser{
-		tagPrefix: tagPrefix,
-		ignoreTag: ignoreTag,
+		tagPrefix:  tagPrefix,
+		ignoreTag:  ignoreTag,
+		schemaName: schemaName,
 	}
 }
 
-func (p *parser) Parse(eb EntityBehavior) (*Table, error) {
+// declaration holds the values that the Entity declares by its methods.
+type declaration struct {
+	tableName     string
+	primaryKey    *PrimaryKey
+	indexes       Indexes
+	grants        Grants
+	localityGroup string
+	schemaName    *string
+	renamedFrom   string
+}
+
+func (p *parser) Parse(eb TableBehavior) (*Table, error) {
+	if e, ok := eb.(*entity); ok {
+		eb = e.TableBehavior
+	}
+
 	columns, err := p.parseStruct(eb, p.tagPrefix)
 	if err != nil {
 		return nil, err
 	}
 
-	return newTable(eb.TableName(), columns, eb.PrimaryKey(), eb.Indexes()), nil
+	return p.newTable(declare(eb), columns)
+}
+
+// declare returns the values that the Entity declares by its methods.
+func declare(eb TableBehavior) *declaration {
+	d := &declaration{tableName: eb.TableName()}
+	if pb, ok := eb.(PrimaryKeyBehavior); ok {
+		d.primaryKey = pb.PrimaryKey()
+	}
+	if ib, ok := eb.(IndexBehavior); ok {
+		d.indexes = ib.Indexes()
+	}
+	if gb, ok := eb.(GrantBehavior); ok {
+		d.grants = gb.Grants()
+	}
+	if lb, ok := eb.(LocalityGroupBehavior); ok {
+		d.localityGroup = lb.LocalityGroup()
+	}
+	if rb, ok := eb.(PreviousTableNameBehavior); ok {
+		d.renamedFrom = rb.PreviousTableName()
+	}
+	if sb, ok := eb.(SchemaBehavior); ok {
+		schemaName := sb.SchemaName()
+		d.schemaName = &schemaName
+	}
+
+	return d
+}
+
+// newTable creates Table from the columns and the declaration.
+func (p *parser) newTable(d *declaration, columns []*Column) (*Table, error) {
+	pk, err := p.parsePrimaryKey(d, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := p.parseIndexes(d, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	t := newTable(d.tableName, columns, pk, indexes)
+	t.grants = d.grants
+	t.localityGroup = d.localityGroup
+	t.renamedFrom = d.renamedFrom
+
+	schemaName := p.schemaName
+	if d.schemaName != nil {
+		schemaName = *d.schemaName
+	}
+	t.qualify(schemaName)
+
+	if err := t.validateGrants(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
 }
 
 func (p *parser) ParseMulti(ebs []EntityBehavior) ([]*Table, error) {
@@ -35,11 +109,11 @@ func (p *parser) ParseMulti(ebs []EntityBehavior) ([]*Table, error) {
 	for i := range ebs {
 		i := i
 		eg.Go(func() error {
-			columns, err := p.parseStruct(ebs[i], p.tagPrefix)
+			t, err := p.Parse(ebs[i])
 			if err != nil {
 				return err
 			}
-			tables[i] = newTable(ebs[i].TableName(), columns, ebs[i].PrimaryKey(), ebs[i].Indexes())
+			tables[i] = t
 
 			return nil
 		})
@@ -52,15 +126,65 @@ func (p *parser) ParseMulti(ebs []EntityBehavior) ([]*Table, error) {
 	return tables, nil
 }
 
+// parsePrimaryKey returns the primary key declared by the `pk` tag or PrimaryKey method.
+// If both are declared, they must be the same.
+func (p *parser) parsePrimaryKey(d *declaration, columns []*Column) (*PrimaryKey, error) {
+	tagPK, err := tagPrimaryKey(columns)
+	if err != nil {
+		return nil, errors.Wrapf(err, "table %s", d.tableName)
+	}
+
+	pk := d.primaryKey
+	switch {
+	case pk == nil && tagPK == nil:
+		return nil, errors.Errorf("table %s: primary key is not declared", d.tableName)
+	case pk == nil:
+		return tagPK, nil
+	case tagPK != nil && !tagPK.equal(pk):
+		return nil, errors.Errorf("table %s: primary key declared by tag %s does not match PrimaryKey() %s", d.tableName, tagPK.ToSQL(), pk.ToSQL())
+	}
+
+	return pk, nil
+}
+
+// parseIndexes returns the indexes declared by the index tags and Indexes method.
+func (p *parser) parseIndexes(d *declaration, columns []*Column) (Indexes, error) {
+	indexes, err := tagIndexes(d.tableName, columns)
+	if err != nil {
+		return nil, errors.Wrapf(err, "table %s", d.tableName)
+	}
+	indexes = append(indexes, d.indexes...)
+
+	names := make(map[string]bool, len(indexes))
+	for _, idx := range indexes {
+		if names[idx.name] {
+			return nil, errors.Errorf("table %s: duplicate index %s", d.tableName, idx.name)
+		}
+		names[idx.name] = true
+	}
+
+	return indexes, nil
+}
+
 func (p *parser) parseStruct(in interface{}, tp string) ([]*Column, error) {
-	v := reflect.Indirect(reflect.ValueOf(in))
-	t := v.Type()
+	return p.parseStructType(reflect.TypeOf(in), nil, tp)
+}
+
+// parseStructType parses the struct type, so the nested struct pointers do not need to be set.
+func (p *parser) parseStructType(t reflect.Type, index []int, tp string) ([]*Column, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, errors.Errorf("%s is not struct", t)
+	}
 
 	columns := make([]*Column, 0, t.NumField())
 	for i := 0; i < t.NumField(); i++ {
 		sf := t.Field(i)
+		fieldIndex := append(append(make([]int, 0, len(index)+1), index...), i)
 		if sf.Type.Kind() == reflect.Ptr && sf.Type.Elem().Kind() == reflect.Struct {
-			cols, err := p.parseStruct(v.Field(i).Interface(), tp)
+			cols, err := p.parseStructType(sf.Type.Elem(), fieldIndex, tp)
 			if err != nil {
 				return nil, err
 			}
@@ -74,6 +198,7 @@ func (p *parser) parseStruct(in interface{}, tp string) ([]*Column, error) {
 
 				return nil, err
 			}
+			col.fieldIndex = fieldIndex
 
 			columns = append(columns, col)
 		}
@@ -83,9 +208,23 @@ func (p *parser) parseStruct(in interface{}, tp string) ([]*Column, error) {
 }
 
 func (p *parser) parseField(field reflect.StructField, tp string) (*Column, error) {
-	t := field.Tag.Get(tp)
+	col, err := p.parseFieldTag(field.Name, field.Tag.Get(tp))
+	if err != nil {
+		return nil, err
+	}
+	col.reflectType = field.Type
+
+	if err := col.validateType(); err != nil {
+		return nil, err
+	}
+
+	return col, nil
+}
+
+// parseFieldTag creates Column of the field from the struct tag value. The type of the column is not set.
+func (p *parser) parseFieldTag(name, t string) (*Column, error) {
 	if t == "" {
-		return newColumn(field.Name, map[string]string{}, field.Type), nil
+		return newTaggedColumn(name, map[string]string{})
 	}
 
 	tags := strings.Split(t, ",")
@@ -104,9 +243,47 @@ func (p *parser) parseField(field reflect.StructField, tp string) (*Column, erro
 		}
 	}
 
-	mts := p.mappingTag(ts)
+	// index tags can be specified multiple times, so they are not mapped.
+	rest := make([]string, 0, len(ts))
+	var its []*indexTag
+	for _, tag := range ts {
+		if !isIndexTag(tag) {
+			rest = append(rest, tag)
+			continue
+		}
+
+		it, err := parseIndexTag(tag)
+		if err != nil {
+			return nil, errors.Wrapf(err, "field %s", name)
+		}
+		its = append(its, it)
+	}
+
+	mts := p.mappingTag(rest)
+
+	col, err := newTaggedColumn(name, mts)
+	if err != nil {
+		return nil, err
+	}
+	col.indexes = its
+
+	return col, nil
+}
+
+// unknownTags returns the elements of the struct tag value whose key is not known by spoon.
+func (p *parser) unknownTags(t string) []string {
+	var unknown []string
+	for _, tag := range strings.Split(t, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == p.ignoreTag || isIndexTag(tag) {
+			continue
+		}
+		if !tagKeys[strings.SplitN(tag, "=", 2)[0]] {
+			unknown = append(unknown, tag)
+		}
+	}
 
-	return newColumn(field.Name, mts, field.Type), nil
+	return unknown
 }
 
 func (p *parser) mappingTag(tags []string) map[string]string {
@@ -0,0 +1,309 @@
+package spoon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// defaultMaxBackfills is the number of the backfills in a DDL batch by default.
+const defaultMaxBackfills = 10
+
+// PhaseKind is the kind of the phase of the online schema change.
+type PhaseKind string
+
+const (
+	// PhaseDDL applies the statements as one DDL batch.
+	PhaseDDL PhaseKind = "DDL"
+	// PhaseBackfill runs the DML as partitioned DML.
+	PhaseBackfill PhaseKind = "BACKFILL"
+	// PhaseCheck runs the query, which must return no row before the next phase starts.
+	PhaseCheck PhaseKind = "CHECK"
+)
+
+// Phases are alias of phase slices.
+type Phases []*Phase
+
+// Phase is the step of the online schema change, which starts after the previous phase has finished.
+type Phase struct {
+	Kind PhaseKind
+	// Statements are the DDL statements of PhaseDDL.
+	Statements Statements
+	// Table is the table that the DML or the query touches.
+	Table string
+	// SQL is the DML of PhaseBackfill, or the query of PhaseCheck.
+	SQL string
+	// Comment describes why the DML or the query is needed.
+	Comment string
+}
+
+// PlanOption sets the optional value of Plan.
+type PlanOption func(*planner)
+
+// MaxBackfills sets the number of the backfills in a DDL batch. Default is 10.
+// The backfills are the statements marked by Statement.Backfill, such as `CREATE INDEX` and `ALTER COLUMN` that validates the existing values.
+// The statements are split into more batches if the backfills exceed it.
+func MaxBackfills(n int) PlanOption {
+	return func(p *planner) {
+		p.maxBackfills = n
+	}
+}
+
+type planner struct {
+	maxBackfills int
+	f            *format
+}
+
+// Plan returns the phases that migrate old schema to s online, splitting the changes that fail on a populated table:
+//
+//   - The column that becomes NOT NULL is added or kept nullable, the nulls are backfilled with the zero value
+//     by partitioned DML, and then the column is altered to NOT NULL.
+//   - The column that is narrowed such as `STRING(MAX)` to `STRING(64)` is altered after the check of the longer values.
+//   - The unique index of the existing table is created after the check of the duplicate keys.
+//
+// The other changes are the same as Diff, and the statements of a DDL phase are split into batches by MaxBackfills.
+func (s *Schema) Plan(old *Schema, opts ...PlanOption) (Phases, error) {
+	p := &planner{maxBackfills: defaultMaxBackfills, f: defaultFormat()}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.maxBackfills < 1 {
+		return nil, errors.Errorf("max backfills %d is less than 1", p.maxBackfills)
+	}
+
+	return p.plan(s, old)
+}
+
+func (p *planner) plan(s, old *Schema) (Phases, error) {
+	// The mid schema is compared with the renamed old schema, and the first DDL phase renames the objects.
+	renamed, err := s.renaming(old, p.f).apply(old)
+	if err != nil {
+		return nil, err
+	}
+
+	var backfills, checks Phases
+	midTables := make([]*Table, 0, len(s.tables))
+	for _, t := range s.tables {
+		ot := renamed.Table(t.name)
+		if ot == nil {
+			midTables = append(midTables, t)
+			continue
+		}
+
+		mt := *t
+		mt.columns = make([]*Column, 0, len(t.columns))
+		for _, c := range t.columns {
+			oc := ot.Column(c.name)
+			ct, tNull := c.columnType()
+			mc := c
+			if oc != nil && isNarrowed(oc, c) {
+				oct, _ := oc.columnType()
+				mc = c.withType(oct, tNull)
+				checks = append(checks, p.lengthCheck(t, c, ct))
+			}
+			if notNull(c) && c.sequenceName == "" && (oc == nil || !notNull(oc)) {
+				if mc == c {
+					mc = c.withType(ct, tNull)
+				}
+				mc.isNull = true
+				backfills = append(backfills, p.nullBackfill(t, c, ct))
+			}
+			mt.columns = append(mt.columns, mc)
+		}
+
+		mt.indexes = make(Indexes, 0, len(t.indexes))
+		for _, idx := range t.indexes {
+			if oi := renamed.Index(idx.name); idx.isUnique && (oi == nil || oi.createIndexSchema(p.f) != idx.createIndexSchema(p.f)) {
+				checks = append(checks, p.uniqueCheck(idx))
+				continue
+			}
+			mt.indexes = append(mt.indexes, idx)
+		}
+		midTables = append(midTables, &mt)
+	}
+
+	mid, err := newSchema(&optionParam{
+		dbName:         s.dbName,
+		dbOptions:      s.dbOptions,
+		sequences:      s.sequences,
+		roles:          s.roles,
+		localityGroups: s.localityGroups,
+	}, midTables)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := mid.diff(old, p.f)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.diff(mid, p.f)
+	if err != nil {
+		return nil, err
+	}
+
+	var ps Phases
+	ps = append(ps, p.batches(before)...)
+	ps = append(ps, backfills...)
+	ps = append(ps, checks...)
+	ps = append(ps, p.batches(after)...)
+
+	return ps, nil
+}
+
+// batches splits the statements into DDL phases, each of which has the backfills up to maxBackfills.
+func (p *planner) batches(ss Statements) Phases {
+	var ps Phases
+	var batch Statements
+	backfills := 0
+	for _, st := range ss {
+		if st.Backfill && backfills == p.maxBackfills {
+			ps = append(ps, &Phase{Kind: PhaseDDL, Statements: batch})
+			batch, backfills = nil, 0
+		}
+		if st.Backfill {
+			backfills++
+		}
+		batch = append(batch, st)
+	}
+	if len(batch) != 0 {
+		ps = append(ps, &Phase{Kind: PhaseDDL, Statements: batch})
+	}
+
+	return ps
+}
+
+func (p *planner) nullBackfill(t *Table, c *Column, ct columnType) *Phase {
+	return &Phase{
+		Kind:    PhaseBackfill,
+		Table:   t.name,
+		SQL:     fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s IS NULL", p.f.quote(t.name), p.f.quote(c.name), zeroValue(ct), p.f.quote(c.name)),
+		Comment: fmt.Sprintf("fill the nulls of %s.%s before NOT NULL", t.name, c.name),
+	}
+}
+
+func (p *planner) lengthCheck(t *Table, c *Column, ct columnType) *Phase {
+	length := "CHAR_LENGTH"
+	if ct.base == typeBytes {
+		length = "BYTE_LENGTH"
+	}
+	cond := fmt.Sprintf("%s(%s) > %d", length, p.f.quote(c.name), ct.size)
+	if ct.array {
+		cond = fmt.Sprintf("EXISTS (SELECT 1 FROM UNNEST(%s) AS v WHERE %s(v) > %d)", p.f.quote(c.name), length, ct.size)
+	}
+
+	return &Phase{
+		Kind:    PhaseCheck,
+		Table:   t.name,
+		SQL:     fmt.Sprintf("SELECT %s FROM %s WHERE %s LIMIT 1", p.keyColumns(t.primaryKey.keyParts), p.f.quote(t.name), cond),
+		Comment: fmt.Sprintf("find the values of %s.%s longer than %d", t.name, c.name, ct.size),
+	}
+}
+
+func (p *planner) uniqueCheck(idx *Index) *Phase {
+	keys := p.keyColumns(idx.keyParts)
+	sql := fmt.Sprintf("SELECT %s FROM %s", keys, p.f.quote(idx.tableName))
+	if idx.nullFiltered {
+		conds := make([]string, 0, len(idx.keyParts))
+		for _, kp := range idx.keyParts {
+			conds = append(conds, p.f.quote(kp.ColumnName)+" IS NOT NULL")
+		}
+		sql += " WHERE " + strings.Join(conds, " AND ")
+	}
+
+	return &Phase{
+		Kind:    PhaseCheck,
+		Table:   idx.tableName,
+		SQL:     fmt.Sprintf("%s GROUP BY %s HAVING COUNT(*) > 1 LIMIT 1", sql, keys),
+		Comment: fmt.Sprintf("find the duplicate keys of unique index %s", idx.name),
+	}
+}
+
+func (p *planner) keyColumns(kps []KeyPart) string {
+	cols := make([]string, 0, len(kps))
+	for _, kp := range kps {
+		cols = append(cols, p.f.quote(kp.ColumnName))
+	}
+
+	return strings.Join(cols, ", ")
+}
+
+// String returns the phases as the commented script.
+func (ps Phases) String() string {
+	var b strings.Builder
+	for i, ph := range ps {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "-- Phase %d: %s", i+1, ph.Kind)
+		if ph.Comment != "" {
+			b.WriteString(", " + ph.Comment)
+		}
+		b.WriteString("\n")
+		if ph.Kind != PhaseDDL {
+			b.WriteString(ph.SQL + ";\n")
+			continue
+		}
+		for _, st := range ph.Statements {
+			b.WriteString(st.SQL + ";\n")
+		}
+	}
+
+	return b.String()
+}
+
+// notNull reports whether the column is NOT NULL.
+func notNull(c *Column) bool {
+	_, tNull := c.columnType()
+
+	return !(c.isNull || tNull)
+}
+
+// isNarrowed reports whether the length of STRING or BYTES column is shortened from old.
+func isNarrowed(old, c *Column) bool {
+	oct, _ := old.columnType()
+	ct, _ := c.columnType()
+	if oct.base != ct.base || oct.array != ct.array || (ct.base != typeString && ct.base != typeBytes) {
+		return false
+	}
+
+	return ct.size != 0 && (oct.size == 0 || ct.size < oct.size)
+}
+
+// withType returns the copy of the column of the type.
+func (c *Column) withType(ct columnType, tNull bool) *Column {
+	cc := *c
+	cc.reflectType = nil
+	cc.staticType = ct
+	cc.staticNull = tNull
+	cc.size = ct.size
+
+	return &cc
+}
+
+// zeroValue returns the literal of the zero value of the type.
+func zeroValue(ct columnType) string {
+	if ct.array {
+		return "ARRAY<" + typeMappings[GoogleSQL].names[ct.base] + ">[]"
+	}
+
+	switch ct.base {
+	case typeBool:
+		return "FALSE"
+	case typeString:
+		return "''"
+	case typeBytes:
+		return "b''"
+	case typeDate:
+		return "DATE '1970-01-01'"
+	case typeTimestamp:
+		return "TIMESTAMP '1970-01-01T00:00:00Z'"
+	case typeJSON:
+		return "JSON 'null'"
+	case typeNumeric:
+		return "NUMERIC '0'"
+	}
+
+	return "0"
+}
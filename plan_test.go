@@ -0,0 +1,81 @@
+package spoon_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+func TestSchema_Plan(t *testing.T) {
+	old, err := spoon.ParseDDL(`CREATE TABLE Singers (
+  SingerID INT64 NOT NULL,
+  Name STRING(MAX) NOT NULL,
+  Nickname STRING(MAX),
+  Tags ARRAY<BYTES(MAX)>,
+) PRIMARY KEY (SingerID);
+CREATE INDEX SingersByName ON Singers (Name);`)
+	if err != nil {
+		t.Fatalf("error ParseDDL old: %v", err)
+	}
+	s, err := spoon.ParseDDL(`CREATE TABLE Singers (
+  SingerID INT64 NOT NULL,
+  Name STRING(64) NOT NULL,
+  Nickname STRING(MAX) NOT NULL,
+  Tags ARRAY<BYTES(16)>,
+  Rank INT64 NOT NULL,
+  Scores ARRAY<FLOAT64> NOT NULL,
+) PRIMARY KEY (SingerID);
+CREATE TABLE Albums (AlbumID INT64 NOT NULL, Title STRING(MAX) NOT NULL) PRIMARY KEY (AlbumID);
+CREATE INDEX SingersByName ON Singers (Name);
+CREATE UNIQUE NULL_FILTERED INDEX SingersByNickname ON Singers (Nickname);
+CREATE INDEX SingersByRank ON Singers (Rank);
+CREATE UNIQUE INDEX AlbumsByTitle ON Albums (Title);`)
+	if err != nil {
+		t.Fatalf("error ParseDDL new: %v", err)
+	}
+
+	ps, err := s.Plan(old, spoon.MaxBackfills(1))
+	if err != nil {
+		t.Fatalf("error Plan: %v", err)
+	}
+
+	expect := "-- Phase 1: DDL\n" +
+		"ALTER TABLE `Singers` ADD COLUMN `Rank` INT64;\n" +
+		"ALTER TABLE `Singers` ADD COLUMN `Scores` ARRAY<FLOAT64>;\n" +
+		"CREATE TABLE `Albums` (\n    `AlbumID` INT64 NOT NULL,\n    `Title` STRING(MAX) NOT NULL,\n) PRIMARY KEY (`AlbumID`);\n" +
+		"CREATE INDEX `SingersByRank` ON `Singers` (`Rank`);\n" +
+		"\n-- Phase 2: DDL\n" +
+		"CREATE UNIQUE INDEX `AlbumsByTitle` ON `Albums` (`Title`);\n" +
+		"\n-- Phase 3: BACKFILL, fill the nulls of Singers.Nickname before NOT NULL\n" +
+		"UPDATE `Singers` SET `Nickname` = '' WHERE `Nickname` IS NULL;\n" +
+		"\n-- Phase 4: BACKFILL, fill the nulls of Singers.Rank before NOT NULL\n" +
+		"UPDATE `Singers` SET `Rank` = 0 WHERE `Rank` IS NULL;\n" +
+		"\n-- Phase 5: BACKFILL, fill the nulls of Singers.Scores before NOT NULL\n" +
+		"UPDATE `Singers` SET `Scores` = ARRAY<FLOAT64>[] WHERE `Scores` IS NULL;\n" +
+		"\n-- Phase 6: CHECK, find the values of Singers.Name longer than 64\n" +
+		"SELECT `SingerID` FROM `Singers` WHERE CHAR_LENGTH(`Name`) > 64 LIMIT 1;\n" +
+		"\n-- Phase 7: CHECK, find the values of Singers.Tags longer than 16\n" +
+		"SELECT `SingerID` FROM `Singers` WHERE EXISTS (SELECT 1 FROM UNNEST(`Tags`) AS v WHERE BYTE_LENGTH(v) > 16) LIMIT 1;\n" +
+		"\n-- Phase 8: CHECK, find the duplicate keys of unique index SingersByNickname\n" +
+		"SELECT `Nickname` FROM `Singers` WHERE `Nickname` IS NOT NULL GROUP BY `Nickname` HAVING COUNT(*) > 1 LIMIT 1;\n" +
+		"\n-- Phase 9: DDL\n" +
+		"ALTER TABLE `Singers` ALTER COLUMN `Name` STRING(64) NOT NULL;\n" +
+		"\n-- Phase 10: DDL\n" +
+		"ALTER TABLE `Singers` ALTER COLUMN `Nickname` STRING(MAX) NOT NULL;\n" +
+		"\n-- Phase 11: DDL\n" +
+		"ALTER TABLE `Singers` ALTER COLUMN `Tags` ARRAY<BYTES(16)>;\n" +
+		"\n-- Phase 12: DDL\n" +
+		"ALTER TABLE `Singers` ALTER COLUMN `Rank` INT64 NOT NULL;\n" +
+		"\n-- Phase 13: DDL\n" +
+		"ALTER TABLE `Singers` ALTER COLUMN `Scores` ARRAY<FLOAT64> NOT NULL;\n" +
+		"\n-- Phase 14: DDL\n" +
+		"CREATE UNIQUE NULL_FILTERED INDEX `SingersByNickname` ON `Singers` (`Nickname`);\n"
+	if diff := cmp.Diff(expect, ps.String()); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+
+	if _, err := s.Plan(old, spoon.MaxBackfills(0)); err == nil {
+		t.Error("expect error of max backfills 0")
+	}
+}
@@ -2,7 +2,10 @@ package spoon
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/pkg/errors"
 )
 
 // PrimaryKey XXX
@@ -11,8 +14,17 @@ type PrimaryKey struct {
 	interleavedTableName string
 }
 
-/*// Columns XXX
-func (pk *PrimaryKey) Columns() []string {
+// KeyParts returns the key parts.
+func (pk *PrimaryKey) KeyParts() []KeyPart {
+	return append([]KeyPart(nil), pk.keyParts...)
+}
+
+// InterleavedTableName returns the parent table name, or empty string if not interleaved.
+func (pk *PrimaryKey) InterleavedTableName() string {
+	return pk.interleavedTableName
+}
+
+func (pk *PrimaryKey) columnNames() []string {
 	cols := make([]string, 0, len(pk.keyParts))
 	for _, kp := range pk.keyParts {
 		cols = append(cols, kp.ColumnName)
@@ -20,25 +32,34 @@ func (pk *PrimaryKey) Columns() []string {
 
 	return cols
 }
-*/
+
 // ToSQL return primary key sql string
 func (pk *PrimaryKey) ToSQL() string {
+	f := defaultFormat()
+	if pk.interleavedTableName != "" {
+		return pk.keySQL(f) + ", " + pk.interleaveSQL(f)
+	}
+
+	return pk.keySQL(f)
+}
+
+func (pk *PrimaryKey) keySQL(f *format) string {
 	var keyPartsStr []string
 	for _, kp := range pk.keyParts {
-		kps := Quote(kp.ColumnName)
+		kps := f.quote(kp.ColumnName)
 		if kp.IsOrderDesc {
 			kps += " DESC"
 		}
 		keyPartsStr = append(keyPartsStr, kps)
 	}
 
-	if pk.interleavedTableName != "" {
-		return fmt.Sprintf(`PRIMARY KEY (%s), INTERLEAVE IN PARENT %s`, strings.Join(keyPartsStr, ", "), Quote(pk.interleavedTableName))
-	}
-
 	return fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(keyPartsStr, ", "))
 }
 
+func (pk *PrimaryKey) interleaveSQL(f *format) string {
+	return fmt.Sprintf("INTERLEAVE IN PARENT %s", f.quote(pk.interleavedTableName))
+}
+
 // AddPrimaryKey XXX
 func AddPrimaryKey(keyParts ...KeyPart) *PrimaryKey {
 	return &PrimaryKey{
@@ -54,3 +75,66 @@ func AddPrimaryKeyWithInterleave(interleaveTableName string, keyParts ...KeyPart
 		interleavedTableName: interleaveTableName,
 	}
 }
+
+func (pk *PrimaryKey) equal(other *PrimaryKey) bool {
+	if pk.interleavedTableName != other.interleavedTableName || len(pk.keyParts) != len(other.keyParts) {
+		return false
+	}
+	for i := range pk.keyParts {
+		if pk.keyParts[i] != other.keyParts[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// tagPrimaryKey creates PrimaryKey from the columns with the `pk` tag.
+// It returns nil if no column has the `pk` tag.
+func tagPrimaryKey(columns []*Column) (*PrimaryKey, error) {
+	var pkCols []*Column
+	for _, c := range columns {
+		if c.primaryKey != nil {
+			pkCols = append(pkCols, c)
+		}
+	}
+	if len(pkCols) == 0 {
+		return nil, nil
+	}
+
+	// All positions must be specified, or none of them.
+	ordered := pkCols[0].primaryKey.order != 0
+	orders := make(map[int]bool, len(pkCols))
+	var interleave string
+	for _, c := range pkCols {
+		if (c.primaryKey.order != 0) != ordered {
+			return nil, errors.Errorf("field %s: pk position must be specified for all key parts or none", c.name)
+		}
+		if orders[c.primaryKey.order] && ordered {
+			return nil, errors.Errorf("field %s: duplicate pk position %d", c.name, c.primaryKey.order)
+		}
+		orders[c.primaryKey.order] = true
+
+		if c.primaryKey.interleave != "" {
+			if interleave != "" && interleave != c.primaryKey.interleave {
+				return nil, errors.Errorf("field %s: interleave %s conflicts with %s", c.name, c.primaryKey.interleave, interleave)
+			}
+			interleave = c.primaryKey.interleave
+		}
+	}
+	sort.SliceStable(pkCols, func(i, j int) bool {
+		return pkCols[i].primaryKey.order < pkCols[j].primaryKey.order
+	})
+	for i, c := range pkCols {
+		if ordered && c.primaryKey.order != i+1 {
+			return nil, errors.Errorf("field %s: pk position %d is missing", c.name, i+1)
+		}
+	}
+
+	keyParts := make([]KeyPart, 0, len(pkCols))
+	for _, c := range pkCols {
+		keyParts = append(keyParts, KeyPart{ColumnName: c.name, IsOrderDesc: c.primaryKey.isOrderDesc})
+	}
+
+	return AddPrimaryKeyWithInterleave(interleave, keyParts...), nil
+}
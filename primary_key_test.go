@@ -2,6 +2,7 @@ package spoon_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pi9min/spoon"
@@ -39,3 +40,146 @@ func TestAddPrimaryKey(t *testing.T) {
 		})
 	}
 }
+
+type TagPK1 struct {
+	ID   int64 `db:"pk"`
+	Name string
+}
+
+func (t *TagPK1) TableName() string {
+	return "TagPK1"
+}
+
+type TagPK2 struct {
+	CreatedAt time.Time `db:"pk=2,desc"`
+	ParentID  int64     `db:"pk=1,interleave=TagPK1"`
+	Comment   string
+}
+
+func (t *TagPK2) TableName() string {
+	return "TagPK2"
+}
+
+type TagPK3 struct {
+	ID   int64 `db:"pk"`
+	Name string
+}
+
+func (t *TagPK3) TableName() string {
+	return "TagPK3"
+}
+
+func (t *TagPK3) PrimaryKey() *spoon.PrimaryKey {
+	return spoon.AddPrimaryKey(spoon.KeyPart{ColumnName: "Name"})
+}
+
+type TagPK4 struct {
+	ID   int64
+	Name string
+}
+
+func (t *TagPK4) TableName() string {
+	return "TagPK4"
+}
+
+type TagPK5 struct {
+	ID   int64  `db:"pk=1"`
+	Name string `db:"pk"`
+}
+
+func (t *TagPK5) TableName() string {
+	return "TagPK5"
+}
+
+type TagPK6 struct {
+	ID   int64  `db:"pk=1"`
+	Name string `db:"pk=3"`
+}
+
+func (t *TagPK6) TableName() string {
+	return "TagPK6"
+}
+
+func TestGenerateCreateTable_TagPrimaryKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		entity    spoon.TableBehavior
+		expect    string
+		expectErr bool
+	}{
+		{
+			name:   "1 pk",
+			entity: &TagPK1{},
+			expect: "CREATE TABLE `TagPK1` (\n    `ID` INT64 NOT NULL,\n    `Name` STRING(MAX) NOT NULL,\n) PRIMARY KEY (`ID`)",
+		},
+		{
+			name:   "2 ordered pk with desc and interleave",
+			entity: &TagPK2{},
+			expect: "CREATE TABLE `TagPK2` (\n    `CreatedAt` TIMESTAMP NOT NULL,\n    `ParentID` INT64 NOT NULL,\n    `Comment` STRING(MAX) NOT NULL,\n) PRIMARY KEY (`ParentID`, `CreatedAt` DESC), INTERLEAVE IN PARENT `TagPK1`",
+		},
+		{
+			name:      "3 pk tag disagrees with PrimaryKey()",
+			entity:    &TagPK3{},
+			expectErr: true,
+		},
+		{
+			name:      "4 no primary key",
+			entity:    &TagPK4{},
+			expectErr: true,
+		},
+		{
+			name:      "5 partially ordered pk",
+			entity:    &TagPK5{},
+			expectErr: true,
+		},
+		{
+			name:      "6 pk position gap",
+			entity:    &TagPK6{},
+			expect:    "table TagPK6: field Name: pk position 2 is missing",
+			expectErr: true,
+		},
+	}
+
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := cli.GenerateCreateTable(tt.entity)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expect error, but nil")
+				} else if tt.expect != "" && err.Error() != tt.expect {
+					t.Errorf("expect error %q, but %q", tt.expect, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error generate create table schema %#v", err)
+			}
+			if diff := cmp.Diff(tt.expect, actual); diff != "" {
+				t.Errorf("GenerateCreateTable Diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntity(t *testing.T) {
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	actual, err := cli.GenerateCreateTables([]spoon.EntityBehavior{spoon.Entity(&TagPK1{}), &Test1{}})
+	if err != nil {
+		t.Fatalf("error generate create tables %#v", err)
+	}
+	if len(actual) != 2 {
+		t.Fatalf("expect 2 tables, but %d", len(actual))
+	}
+	if diff := cmp.Diff("CREATE TABLE `TagPK1` (\n    `ID` INT64 NOT NULL,\n    `Name` STRING(MAX) NOT NULL,\n) PRIMARY KEY (`ID`)", actual[0]); diff != "" {
+		t.Errorf("GenerateCreateTables Diff:\n%s", diff)
+	}
+}
@@ -0,0 +1,259 @@
+package spoon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// renaming holds the tables and the columns renamed from old schema.
+type renaming struct {
+	// tables maps the previous table name to the new name.
+	tables map[string]string
+	// columns maps the new table name to the map of the previous column name to the new name.
+	columns map[string]map[string]string
+	// ss are the statements that rename the objects, and reverts are the statements that revert them.
+	ss      Statements
+	reverts Statements
+}
+
+// renaming returns the tables and the columns of old schema renamed by PreviousTableName and the `renamed_from` tag.
+// The previous name is ignored if old schema does not have it, so the schema that has been renamed is not renamed again.
+func (s *Schema) renaming(old *Schema, f *format) *renaming {
+	r := newRenaming()
+	var columnReverts Statements
+	for _, t := range s.tables {
+		if t.renamedFrom == "" || old.Table(t.name) != nil || old.Table(t.renamedFrom) == nil || s.Table(t.renamedFrom) != nil {
+			continue
+		}
+		r.tables[t.renamedFrom] = t.name
+		r.ss = append(r.ss, &Statement{
+			Kind:       StatementAlter,
+			ObjectType: ObjectTable,
+			Object:     t.name,
+			Table:      t.name,
+			SQL:        fmt.Sprintf("ALTER TABLE %s RENAME TO %s, ADD SYNONYM %s", f.quote(t.renamedFrom), f.quote(t.name), f.quote(t.renamedFrom)),
+		})
+		r.reverts = append(r.reverts, &Statement{
+			Kind:       StatementAlter,
+			ObjectType: ObjectTable,
+			Object:     t.name,
+			Table:      t.name,
+			SQL:        fmt.Sprintf("ALTER TABLE %s DROP SYNONYM %s", f.quote(t.name), f.quote(t.renamedFrom)),
+		}, &Statement{
+			Kind:       StatementAlter,
+			ObjectType: ObjectTable,
+			Object:     t.renamedFrom,
+			Table:      t.renamedFrom,
+			SQL:        fmt.Sprintf("ALTER TABLE %s RENAME TO %s", f.quote(t.name), f.quote(t.renamedFrom)),
+		})
+	}
+
+	for _, t := range s.tables {
+		ot := old.Table(t.name)
+		if from, ok := r.previousTableName(t.name); ok {
+			ot = old.Table(from)
+		}
+		if ot == nil {
+			continue
+		}
+		for _, c := range t.columns {
+			if c.renamedFrom == "" || ot.Column(c.name) != nil || ot.Column(c.renamedFrom) == nil || t.Column(c.renamedFrom) != nil {
+				continue
+			}
+			if r.columns[t.name] == nil {
+				r.columns[t.name] = make(map[string]string)
+			}
+			r.columns[t.name][c.renamedFrom] = c.name
+			r.ss = append(r.ss, &Statement{
+				Kind:       StatementAlter,
+				ObjectType: ObjectTable,
+				Object:     t.name,
+				Table:      t.name,
+				SQL:        fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s", f.quote(t.name), f.quote(c.renamedFrom), f.quote(c.name)),
+			})
+			columnReverts = append(columnReverts, &Statement{
+				Kind:       StatementAlter,
+				ObjectType: ObjectTable,
+				Object:     t.name,
+				Table:      t.name,
+				SQL:        fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s", f.quote(t.name), f.quote(c.name), f.quote(c.renamedFrom)),
+			})
+		}
+	}
+	// The columns are renamed back before the table, because the statements refer to the new table name.
+	r.reverts = append(columnReverts, r.reverts...)
+
+	return r
+}
+
+func newRenaming() *renaming {
+	return &renaming{tables: make(map[string]string), columns: make(map[string]map[string]string)}
+}
+
+// inverse returns the renaming that reverts r. The synonym added by r is dropped.
+func (r *renaming) inverse() *renaming {
+	inv := newRenaming()
+	for from, to := range r.tables {
+		inv.tables[to] = from
+	}
+	for tableName, columns := range r.columns {
+		if from, ok := r.previousTableName(tableName); ok {
+			tableName = from
+		}
+		inv.columns[tableName] = make(map[string]string, len(columns))
+		for from, to := range columns {
+			inv.columns[tableName][to] = from
+		}
+	}
+	inv.ss, inv.reverts = r.reverts, r.ss
+
+	return inv
+}
+
+func (r *renaming) previousTableName(name string) (string, bool) {
+	for from, to := range r.tables {
+		if to == name {
+			return from, true
+		}
+	}
+
+	return "", false
+}
+
+func (r *renaming) previousColumnName(tableName, name string) (string, bool) {
+	for from, to := range r.columns[tableName] {
+		if to == name {
+			return from, true
+		}
+	}
+
+	return "", false
+}
+
+func (r *renaming) table(name string) string {
+	if to, ok := r.tables[name]; ok {
+		return to
+	}
+
+	return name
+}
+
+func (r *renaming) column(tableName, name string) string {
+	if to, ok := r.columns[tableName][name]; ok {
+		return to
+	}
+
+	return name
+}
+
+func (r *renaming) keyParts(tableName string, kps []KeyPart) []KeyPart {
+	renamed := make([]KeyPart, 0, len(kps))
+	for _, kp := range kps {
+		kp.ColumnName = r.column(tableName, kp.ColumnName)
+		renamed = append(renamed, kp)
+	}
+
+	return renamed
+}
+
+// apply returns old schema whose tables and columns are renamed,
+// and the indexes, the grants and the interleaved children follow them.
+func (r *renaming) apply(old *Schema) (*Schema, error) {
+	if len(r.tables) == 0 && len(r.columns) == 0 {
+		return old, nil
+	}
+
+	tables := make([]*Table, 0, len(old.tables))
+	for _, ot := range old.tables {
+		t := *ot
+		t.name = r.table(ot.name)
+		t.schemaName = ""
+		if i := strings.LastIndex(t.name, "."); i >= 0 {
+			t.schemaName = t.name[:i]
+		}
+
+		t.columns = make([]*Column, 0, len(ot.columns))
+		for _, oc := range ot.columns {
+			c := *oc
+			c.name = r.column(t.name, oc.name)
+			t.columns = append(t.columns, &c)
+		}
+
+		pk := *ot.primaryKey
+		pk.keyParts = r.keyParts(t.name, pk.keyParts)
+		if pk.interleavedTableName != "" {
+			pk.interleavedTableName = r.table(pk.interleavedTableName)
+		}
+		t.primaryKey = &pk
+
+		t.indexes = make(Indexes, 0, len(ot.indexes))
+		for _, oi := range ot.indexes {
+			idx := *oi
+			idx.tableName = t.name
+			idx.keyParts = r.keyParts(t.name, oi.keyParts)
+			t.indexes = append(t.indexes, &idx)
+		}
+
+		t.grants = make(Grants, 0, len(ot.grants))
+		for _, og := range ot.grants {
+			g := *og
+			g.tableName = t.name
+			g.columns = make([]string, 0, len(og.columns))
+			for _, c := range og.columns {
+				g.columns = append(g.columns, r.column(t.name, c))
+			}
+			t.grants = append(t.grants, &g)
+		}
+		tables = append(tables, &t)
+	}
+
+	return newSchema(&optionParam{
+		dbName:         old.dbName,
+		dbOptions:      old.dbOptions,
+		sequences:      old.sequences,
+		roles:          old.roles,
+		localityGroups: old.localityGroups,
+	}, tables)
+}
+
+// validateRenamedTables checks that each previous table name is declared once and is not the name of a table,
+// because the renames would conflict.
+func (s *Schema) validateRenamedTables() error {
+	renamedBy := make(map[string]string)
+	for _, t := range s.tables {
+		if t.renamedFrom == "" {
+			continue
+		}
+		if other, ok := renamedBy[t.renamedFrom]; ok {
+			return errors.Errorf("table %s: previous name %s is also declared by table %s", t.name, t.renamedFrom, other)
+		}
+		if s.Table(t.renamedFrom) != nil {
+			return errors.Errorf("table %s: previous name %s is the name of an existing table", t.name, t.renamedFrom)
+		}
+		renamedBy[t.renamedFrom] = t.name
+	}
+
+	return nil
+}
+
+// validateRenamedColumns checks that each previous column name is declared once and is not the name of a column,
+// because the renames would conflict.
+func (t *Table) validateRenamedColumns() error {
+	renamedBy := make(map[string]string)
+	for _, c := range t.columns {
+		if c.renamedFrom == "" {
+			continue
+		}
+		if other, ok := renamedBy[c.renamedFrom]; ok {
+			return errors.Errorf("table %s: previous name %s of column %s is also declared by column %s", t.name, c.renamedFrom, c.name, other)
+		}
+		if t.Column(c.renamedFrom) != nil {
+			return errors.Errorf("table %s: previous name %s of column %s is the name of an existing column", t.name, c.renamedFrom, c.name)
+		}
+		renamedBy[c.renamedFrom] = c.name
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package spoon_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+type Test10 struct {
+	ID    int64  `db:"pk"`
+	Title string `db:"renamed_from=Name,index=Test10ByTitle"`
+}
+
+func (t *Test10) TableName() string {
+	return "Test10"
+}
+
+func (t *Test10) PreviousTableName() string {
+	return "Legacy10"
+}
+
+type Test10Child struct {
+	ID      int64 `db:"pk=1,interleave=Test10"`
+	ChildID int64 `db:"pk=2"`
+}
+
+func (t *Test10Child) TableName() string {
+	return "Test10Child"
+}
+
+const test10Old = `CREATE TABLE Legacy10 (ID INT64 NOT NULL, Name STRING(MAX) NOT NULL) PRIMARY KEY (ID);
+CREATE INDEX Test10ByTitle ON Legacy10 (Name);
+CREATE TABLE Test10Child (ID INT64 NOT NULL, ChildID INT64 NOT NULL) PRIMARY KEY (ID, ChildID), INTERLEAVE IN PARENT Legacy10;`
+
+func TestSchema_Diff_Rename(t *testing.T) {
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new client: %v", err)
+	}
+	s, err := cli.BuildSchema([]spoon.EntityBehavior{spoon.Entity(&Test10{}), spoon.Entity(&Test10Child{})})
+	if err != nil {
+		t.Fatalf("error BuildSchema: %v", err)
+	}
+	old, err := spoon.ParseDDL(test10Old)
+	if err != nil {
+		t.Fatalf("error ParseDDL old: %v", err)
+	}
+
+	ss, err := s.Diff(old)
+	if err != nil {
+		t.Fatalf("error Diff: %v", err)
+	}
+	expect := []string{
+		"ALTER TABLE `Legacy10` RENAME TO `Test10`, ADD SYNONYM `Legacy10`",
+		"ALTER TABLE `Test10` RENAME COLUMN `Name` TO `Title`",
+	}
+	if diff := cmp.Diff(expect, ss.SQL()); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+
+	// The renamed schema is not renamed again.
+	renamed, err := spoon.ParseDDL(test10Old + "\n" + strings.Join(ss.SQL(), ";\n"))
+	if err != nil {
+		t.Fatalf("error ParseDDL renamed: %v", err)
+	}
+	ss, err = s.Diff(renamed)
+	if err != nil {
+		t.Fatalf("error Diff: %v", err)
+	}
+	if diff := cmp.Diff([]string{}, ss.SQL()); diff != "" {
+		t.Errorf("Diff of renamed:\n%s", diff)
+	}
+
+	down, is, err := s.ReverseDiff(old)
+	if err != nil {
+		t.Fatalf("error ReverseDiff: %v", err)
+	}
+	expectDown := []string{
+		"ALTER TABLE `Test10` RENAME COLUMN `Title` TO `Name`",
+		"ALTER TABLE `Test10` DROP SYNONYM `Legacy10`",
+		"ALTER TABLE `Test10` RENAME TO `Legacy10`",
+	}
+	if diff := cmp.Diff(expectDown, down.SQL()); diff != "" {
+		t.Errorf("ReverseDiff:\n%s", diff)
+	}
+	if len(is) != 0 {
+		t.Errorf("unexpected irreversibles %v", is)
+	}
+
+	ps, err := s.Plan(old)
+	if err != nil {
+		t.Fatalf("error Plan: %v", err)
+	}
+	expectPlan := "-- Phase 1: DDL\n" + strings.Join(expect, ";\n") + ";\n"
+	if diff := cmp.Diff(expectPlan, ps.String()); diff != "" {
+		t.Errorf("Plan Diff:\n%s", diff)
+	}
+
+	expectChanges := spoon.Changes{
+		{Object: "TABLE Test10", Compatibility: spoon.CompatibilitySafe, Message: "table is renamed from Legacy10, and the synonym Legacy10 keeps the running versions working"},
+		{Object: "COLUMN Test10.Title", Compatibility: spoon.CompatibilityBreaking, Message: "column is renamed from Name, so the running versions fail to access it"},
+	}
+	cs, err := s.CheckCompatibility(old)
+	if err != nil {
+		t.Fatalf("error CheckCompatibility: %v", err)
+	}
+	if diff := cmp.Diff(expectChanges, cs); diff != "" {
+		t.Errorf("CheckCompatibility Diff:\n%s", diff)
+	}
+}
+
+type Test10Renamed struct {
+	ID int64 `db:"pk"`
+}
+
+func (t *Test10Renamed) TableName() string {
+	return "Test10Renamed"
+}
+
+func (t *Test10Renamed) PreviousTableName() string {
+	return "Legacy10"
+}
+
+type Test10Shadow struct {
+	ID int64 `db:"pk"`
+}
+
+func (t *Test10Shadow) TableName() string {
+	return "Test10Shadow"
+}
+
+func (t *Test10Shadow) PreviousTableName() string {
+	return "Test10"
+}
+
+type Test10DuplicateColumn struct {
+	ID       int64  `db:"pk"`
+	Title    string `db:"renamed_from=Name"`
+	Subtitle string `db:"renamed_from=Name"`
+}
+
+func (t *Test10DuplicateColumn) TableName() string {
+	return "Test10DuplicateColumn"
+}
+
+type Test10ExistingColumn struct {
+	ID    int64  `db:"pk"`
+	Name  string `db:"renamed_from=Title"`
+	Title string
+}
+
+func (t *Test10ExistingColumn) TableName() string {
+	return "Test10ExistingColumn"
+}
+
+func TestSchema_Validate_Rename(t *testing.T) {
+	tests := []struct {
+		name     string
+		entities []spoon.EntityBehavior
+		expect   string
+	}{
+		{
+			name:     "duplicate previous table name",
+			entities: []spoon.EntityBehavior{spoon.Entity(&Test10{}), spoon.Entity(&Test10Renamed{})},
+			expect:   "table Test10Renamed: previous name Legacy10 is also declared by table Test10",
+		},
+		{
+			name:     "previous table name of an existing table",
+			entities: []spoon.EntityBehavior{spoon.Entity(&Test10{}), spoon.Entity(&Test10Shadow{})},
+			expect:   "table Test10Shadow: previous name Test10 is the name of an existing table",
+		},
+		{
+			name:     "duplicate previous column name",
+			entities: []spoon.EntityBehavior{spoon.Entity(&Test10DuplicateColumn{})},
+			expect:   "table Test10DuplicateColumn: previous name Name of column Subtitle is also declared by column Title",
+		},
+		{
+			name:     "previous column name of an existing column",
+			entities: []spoon.EntityBehavior{spoon.Entity(&Test10ExistingColumn{})},
+			expect:   "table Test10ExistingColumn: previous name Title of column Name is the name of an existing column",
+		},
+	}
+
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new client: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := cli.BuildSchema(tt.entities)
+			if err != nil {
+				t.Fatalf("error BuildSchema: %v", err)
+			}
+			err = s.Validate()
+			if err == nil {
+				t.Fatal("expect error, but nil")
+			}
+			if diff := cmp.Diff(tt.expect, err.Error()); diff != "" {
+				t.Errorf("Diff:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package spoon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Privilege is the privilege granted to the role.
+type Privilege string
+
+const (
+	PrivilegeSelect Privilege = "SELECT"
+	PrivilegeInsert Privilege = "INSERT"
+	PrivilegeUpdate Privilege = "UPDATE"
+	PrivilegeDelete Privilege = "DELETE"
+)
+
+// GrantBehavior defines the interface that declares the privileges of an Entity.
+type GrantBehavior interface {
+	Grants() Grants
+}
+
+// Roles are alias of role slices.
+type Roles []*Role
+
+// Role holds the necessary information to construct database role.
+type Role struct {
+	name string
+}
+
+// AddRole creates Role.
+func AddRole(name string) *Role {
+	return &Role{
+		name: name,
+	}
+}
+
+// CreateRoleSchema return `CREATE ROLE` schema.
+func (r *Role) CreateRoleSchema() string {
+	return r.createRoleSchema(defaultFormat())
+}
+
+func (r *Role) createRoleSchema(f *format) string {
+	return fmt.Sprintf("CREATE ROLE %s", f.quote(r.name))
+}
+
+// DropRoleSchema return `DROP ROLE` schema.
+func (r *Role) DropRoleSchema() string {
+	return r.dropRoleSchema(defaultFormat())
+}
+
+func (r *Role) dropRoleSchema(f *format) string {
+	return fmt.Sprintf("DROP ROLE %s", f.quote(r.name))
+}
+
+// Grants are alias of grant slices.
+type Grants []*Grant
+
+// Grant holds the privilege of the role on the table or its columns.
+type Grant struct {
+	roleName  string
+	privilege Privilege
+	tableName string
+	columns   []string
+}
+
+// AddGrant creates Grant of the privilege on the whole table.
+func AddGrant(roleName string, privilege Privilege, tableName string) *Grant {
+	return &Grant{
+		roleName:  roleName,
+		privilege: privilege,
+		tableName: tableName,
+	}
+}
+
+// AddColumnGrant creates Grant of the privilege on the columns of the table.
+func AddColumnGrant(roleName string, privilege Privilege, tableName string, columns ...string) *Grant {
+	return &Grant{
+		roleName:  roleName,
+		privilege: privilege,
+		tableName: tableName,
+		columns:   columns,
+	}
+}
+
+// GrantSchema return `GRANT` schema.
+func (g *Grant) GrantSchema() string {
+	return g.grantSchema(defaultFormat())
+}
+
+func (g *Grant) grantSchema(f *format) string {
+	return fmt.Sprintf("GRANT %s ON TABLE %s TO %s", g.privilegeSQL(f), f.quote(g.tableName), g.roleSQL(f))
+}
+
+// RevokeSchema return `REVOKE` schema.
+func (g *Grant) RevokeSchema() string {
+	return g.revokeSchema(defaultFormat())
+}
+
+func (g *Grant) revokeSchema(f *format) string {
+	return fmt.Sprintf("REVOKE %s ON TABLE %s FROM %s", g.privilegeSQL(f), f.quote(g.tableName), g.roleSQL(f))
+}
+
+func (g *Grant) privilegeSQL(f *format) string {
+	if len(g.columns) == 0 {
+		return string(g.privilege)
+	}
+
+	cols := make([]string, 0, len(g.columns))
+	for _, c := range g.columns {
+		cols = append(cols, f.quote(c))
+	}
+
+	if f.dialect == PostgreSQL {
+		return fmt.Sprintf("%s (%s)", g.privilege, strings.Join(cols, ", "))
+	}
+
+	return fmt.Sprintf("%s(%s)", g.privilege, strings.Join(cols, ", "))
+}
+
+// roleSQL returns the grantee. PostgreSQL dialect does not take the `ROLE` keyword.
+func (g *Grant) roleSQL(f *format) string {
+	if f.dialect == PostgreSQL {
+		return f.quote(g.roleName)
+	}
+
+	return "ROLE " + f.quote(g.roleName)
+}
+
+func (t *Table) validateGrants() error {
+	for _, g := range t.grants {
+		switch g.privilege {
+		case PrivilegeSelect, PrivilegeInsert, PrivilegeUpdate:
+		case PrivilegeDelete:
+			if len(g.columns) != 0 {
+				return errors.Errorf("table %s: DELETE privilege can not be granted on columns", t.name)
+			}
+		default:
+			return errors.Errorf("table %s: unknown privilege %s", t.name, g.privilege)
+		}
+
+		if g.tableName != t.name {
+			return errors.Errorf("table %s: grant to role %s is on table %s", t.name, g.roleName, g.tableName)
+		}
+
+		for _, c := range g.columns {
+			if t.Column(c) == nil {
+				return errors.Errorf("table %s: granted column %s does not exist", t.name, c)
+			}
+		}
+	}
+
+	return nil
+}
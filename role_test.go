@@ -0,0 +1,142 @@
+package spoon_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+type Test5 struct {
+	ID     int64
+	Name   string
+	Amount int64
+}
+
+func (t5 *Test5) TableName() string {
+	return "Test5"
+}
+
+func (t5 *Test5) Indexes() spoon.Indexes {
+	return spoon.Indexes{}
+}
+
+func (t5 *Test5) PrimaryKey() *spoon.PrimaryKey {
+	return spoon.AddPrimaryKey(spoon.KeyPart{ColumnName: "ID"})
+}
+
+func (t5 *Test5) Grants() spoon.Grants {
+	return spoon.Grants{
+		spoon.AddColumnGrant("analyst", spoon.PrivilegeSelect, "Test5", "ID", "Name"),
+		spoon.AddGrant("writer", spoon.PrivilegeInsert, "Test5"),
+	}
+}
+
+type Test6 struct {
+	ID int64
+}
+
+func (t6 *Test6) TableName() string {
+	return "Test6"
+}
+
+func (t6 *Test6) Indexes() spoon.Indexes {
+	return spoon.Indexes{}
+}
+
+func (t6 *Test6) PrimaryKey() *spoon.PrimaryKey {
+	return spoon.AddPrimaryKey(spoon.KeyPart{ColumnName: "ID"})
+}
+
+func (t6 *Test6) Grants() spoon.Grants {
+	return spoon.Grants{
+		spoon.AddColumnGrant("analyst", spoon.PrivilegeSelect, "Test6", "Unknown"),
+	}
+}
+
+func TestAddRole(t *testing.T) {
+	r := spoon.AddRole("analyst")
+	if diff := cmp.Diff("CREATE ROLE `analyst`", r.CreateRoleSchema()); diff != "" {
+		t.Errorf("CreateRoleSchema Diff:\n%s", diff)
+	}
+	if diff := cmp.Diff("DROP ROLE `analyst`", r.DropRoleSchema()); diff != "" {
+		t.Errorf("DropRoleSchema Diff:\n%s", diff)
+	}
+}
+
+func TestAddGrant(t *testing.T) {
+	tests := []struct {
+		name         string
+		grant        *spoon.Grant
+		expectGrant  string
+		expectRevoke string
+	}{
+		{
+			name:         "1 table",
+			grant:        spoon.AddGrant("writer", spoon.PrivilegeInsert, "Player"),
+			expectGrant:  "GRANT INSERT ON TABLE `Player` TO ROLE `writer`",
+			expectRevoke: "REVOKE INSERT ON TABLE `Player` FROM ROLE `writer`",
+		},
+		{
+			name:         "2 columns",
+			grant:        spoon.AddColumnGrant("analyst", spoon.PrivilegeSelect, "Player", "PlayerID", "Name"),
+			expectGrant:  "GRANT SELECT(`PlayerID`, `Name`) ON TABLE `Player` TO ROLE `analyst`",
+			expectRevoke: "REVOKE SELECT(`PlayerID`, `Name`) ON TABLE `Player` FROM ROLE `analyst`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expectGrant, tt.grant.GrantSchema()); diff != "" {
+				t.Errorf("GrantSchema Diff:\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.expectRevoke, tt.grant.RevokeSchema()); diff != "" {
+				t.Errorf("RevokeSchema Diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateGrants(t *testing.T) {
+	cli, err := spoon.New(spoon.WithRoles(spoon.AddRole("analyst"), spoon.AddRole("writer")))
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	actual, err := cli.GenerateGrants(&Test5{})
+	if err != nil {
+		t.Fatalf("error generate grants %#v", err)
+	}
+	expect := []string{
+		"GRANT SELECT(`ID`, `Name`) ON TABLE `Test5` TO ROLE `analyst`",
+		"GRANT INSERT ON TABLE `Test5` TO ROLE `writer`",
+	}
+	if diff := cmp.Diff(expect, actual); diff != "" {
+		t.Errorf("GenerateGrants Diff:\n%s", diff)
+	}
+
+	schemas, err := cli.GenerateSchema([]spoon.EntityBehavior{&Test5{}})
+	if err != nil {
+		t.Fatalf("error generate schema %#v", err)
+	}
+	expectSchemas := append([]string{
+		"CREATE TABLE `Test5` (\n    `ID` INT64 NOT NULL,\n    `Name` STRING(MAX) NOT NULL,\n    `Amount` INT64 NOT NULL,\n) PRIMARY KEY (`ID`)",
+		"CREATE ROLE `analyst`",
+		"CREATE ROLE `writer`",
+	}, expect...)
+	if diff := cmp.Diff(expectSchemas, schemas); diff != "" {
+		t.Errorf("GenerateSchema Diff:\n%s", diff)
+	}
+
+	if _, err := cli.GenerateGrants(&Test6{}); err == nil {
+		t.Errorf("expect error unknown granted column")
+	}
+
+	noRoleCli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+	if _, err := noRoleCli.GenerateSchema([]spoon.EntityBehavior{&Test5{}}); err == nil {
+		t.Errorf("expect error undefined role")
+	}
+}
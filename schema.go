@@ -0,0 +1,332 @@
+package spoon
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Schema holds all tables, indexes and other objects of the database.
+type Schema struct {
+	dbName         string
+	dbOptions      DatabaseOptions
+	sequences      Sequences
+	roles          Roles
+	localityGroups LocalityGroups
+	tables         []*Table
+	tableByName    map[string]*Table
+	indexByName    map[string]*Index
+}
+
+// BuildSchema parses the specified Entity and returns Schema with the objects set to Client.
+// The tables are sorted in dependency order, so the interleaved parent comes before its children.
+func (c *Client) BuildSchema(ebs []EntityBehavior) (*Schema, error) {
+	tables, err := c.parser.ParseMulti(ebs)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSchema(c.param, tables)
+}
+
+func newSchema(param *optionParam, tables []*Table) (*Schema, error) {
+	s := &Schema{
+		dbName:         param.dbName,
+		dbOptions:      param.dbOptions,
+		sequences:      param.sequences,
+		roles:          param.roles,
+		localityGroups: param.localityGroups,
+		tableByName:    make(map[string]*Table, len(tables)),
+		indexByName:    make(map[string]*Index),
+	}
+
+	for _, t := range tables {
+		if _, ok := s.tableByName[t.name]; ok {
+			return nil, errors.Errorf("duplicate table %s", t.name)
+		}
+		s.tableByName[t.name] = t
+
+		for _, idx := range t.indexes {
+			if _, ok := s.indexByName[idx.name]; ok {
+				return nil, errors.Errorf("duplicate index %s", idx.name)
+			}
+			s.indexByName[idx.name] = idx
+		}
+	}
+
+	for _, t := range tables {
+		t.resolveParent(s.tableByName)
+	}
+
+	sorted, err := sortTables(tables, s.tableByName)
+	if err != nil {
+		return nil, err
+	}
+	s.tables = sorted
+
+	return s, nil
+}
+
+// sortTables sorts the tables so that the interleaved parent comes first, keeping the given order as much as possible.
+func sortTables(tables []*Table, byName map[string]*Table) ([]*Table, error) {
+	sorted := make([]*Table, 0, len(tables))
+	done := make(map[string]bool, len(tables))
+	visiting := make(map[string]bool)
+
+	var visit func(t *Table) error
+	visit = func(t *Table) error {
+		if done[t.name] {
+			return nil
+		}
+		if visiting[t.name] {
+			return errors.Errorf("table %s: interleave cycle", t.name)
+		}
+		visiting[t.name] = true
+
+		if t.primaryKey != nil {
+			if parent, ok := byName[t.primaryKey.interleavedTableName]; ok {
+				if err := visit(parent); err != nil {
+					return err
+				}
+			}
+		}
+
+		visiting[t.name] = false
+		done[t.name] = true
+		sorted = append(sorted, t)
+		return nil
+	}
+
+	for _, t := range tables {
+		if err := visit(t); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
+
+// Tables returns the tables in dependency order.
+func (s *Schema) Tables() []*Table {
+	return append([]*Table(nil), s.tables...)
+}
+
+// Table returns the table of the name, or nil if it does not exist.
+func (s *Schema) Table(name string) *Table {
+	return s.tableByName[name]
+}
+
+// Index returns the index of the name, or nil if it does not exist.
+func (s *Schema) Index(name string) *Index {
+	return s.indexByName[name]
+}
+
+// Indexes returns the indexes of all tables in dependency order of the tables.
+func (s *Schema) Indexes() Indexes {
+	var indexes Indexes
+	for _, t := range s.tables {
+		indexes = append(indexes, t.indexes...)
+	}
+
+	return indexes
+}
+
+// NamedSchemas returns the named schemas used by the tables.
+func (s *Schema) NamedSchemas() NamedSchemas {
+	return namedSchemas(s.tables)
+}
+
+// Sequences returns the sequences.
+func (s *Schema) Sequences() Sequences {
+	return s.sequences
+}
+
+// Roles returns the roles.
+func (s *Schema) Roles() Roles {
+	return s.roles
+}
+
+// LocalityGroups returns the locality groups.
+func (s *Schema) LocalityGroups() LocalityGroups {
+	return s.localityGroups
+}
+
+// DatabaseOptions returns the database name and options.
+func (s *Schema) DatabaseOptions() (string, DatabaseOptions) {
+	return s.dbName, s.dbOptions
+}
+
+// Validate checks the references between the objects.
+func (s *Schema) Validate() error {
+	seqs := make(map[string]bool, len(s.sequences))
+	for _, seq := range s.sequences {
+		seqs[seq.name] = true
+	}
+	roles := make(map[string]bool, len(s.roles))
+	for _, r := range s.roles {
+		roles[r.name] = true
+	}
+	lgs := make(map[string]bool, len(s.localityGroups))
+	for _, lg := range s.localityGroups {
+		lgs[lg.name] = true
+	}
+
+	if err := s.validateRenamedTables(); err != nil {
+		return err
+	}
+
+	for _, t := range s.tables {
+		for _, lg := range t.localityGroups() {
+			if !lgs[lg] {
+				return errors.Errorf("table %s: locality group %s is not defined", t.name, lg)
+			}
+		}
+		for _, c := range t.columns {
+			if c.sequenceName != "" && !seqs[c.sequenceName] {
+				return errors.Errorf("table %s: sequence %s is not defined", t.name, c.sequenceName)
+			}
+		}
+		for _, g := range t.grants {
+			if !roles[g.roleName] {
+				return errors.Errorf("table %s: role %s is not defined", t.name, g.roleName)
+			}
+		}
+
+		if err := s.validatePrimaryKey(t); err != nil {
+			return err
+		}
+		if err := t.validateRenamedColumns(); err != nil {
+			return err
+		}
+		for _, idx := range t.indexes {
+			if idx.tableName != t.name {
+				return errors.Errorf("index %s: table %s does not match %s", idx.name, idx.tableName, t.name)
+			}
+			for _, kp := range idx.keyParts {
+				if t.Column(kp.ColumnName) == nil {
+					return errors.Errorf("index %s: column %s does not exist in %s", idx.name, kp.ColumnName, t.name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validatePrimaryKey checks that the key columns exist and the primary key of the interleaved parent is its prefix.
+func (s *Schema) validatePrimaryKey(t *Table) error {
+	for _, kp := range t.primaryKey.keyParts {
+		if t.Column(kp.ColumnName) == nil {
+			return errors.Errorf("table %s: primary key column %s does not exist", t.name, kp.ColumnName)
+		}
+	}
+
+	if t.primaryKey.interleavedTableName == "" {
+		return nil
+	}
+	parent, ok := s.tableByName[t.primaryKey.interleavedTableName]
+	if !ok {
+		return errors.Errorf("table %s: interleaved parent %s does not exist", t.name, t.primaryKey.interleavedTableName)
+	}
+	if len(parent.primaryKey.keyParts) >= len(t.primaryKey.keyParts) {
+		return errors.Errorf("table %s: primary key must have more key parts than parent %s", t.name, parent.name)
+	}
+	for i, kp := range parent.primaryKey.keyParts {
+		if t.primaryKey.keyParts[i] != kp {
+			return errors.Errorf("table %s: primary key must start with the primary key of parent %s", t.name, parent.name)
+		}
+	}
+
+	return nil
+}
+
+// Statements returns the statements that create the whole schema.
+// The `ALTER DATABASE` schema, named schemas, sequences and locality groups come first,
+// followed by the `CREATE TABLE` and `CREATE INDEX` schemas, and the roles and their grants come at the end.
+func (s *Schema) Statements() Statements {
+	return s.statements(defaultFormat())
+}
+
+func (s *Schema) statements(f *format) Statements {
+	var ss Statements
+	if st := alterDatabaseStatement(s.dbName, s.dbOptions.AlterDatabaseSchema(s.dbName)); st != nil {
+		ss = append(ss, st)
+	}
+	for _, ns := range s.NamedSchemas() {
+		ss = append(ss, ns.createNamedSchemaStatement(f))
+	}
+	for _, seq := range s.sequences {
+		ss = append(ss, seq.createSequenceStatement(f))
+	}
+	for _, lg := range s.localityGroups {
+		ss = append(ss, lg.CreateLocalityGroupStatement())
+	}
+	for _, t := range s.tables {
+		ss = append(ss, t.createTableStatement(f))
+	}
+	for _, idx := range s.Indexes() {
+		ss = append(ss, idx.createIndexStatement(f))
+	}
+	for _, r := range s.roles {
+		ss = append(ss, r.createRoleStatement(f))
+	}
+	for _, t := range s.tables {
+		for _, g := range t.grants {
+			ss = append(ss, g.grantStatement(f))
+		}
+	}
+
+	return ss
+}
+
+// DropStatements returns the statements that drop the whole schema in reverse order of Statements.
+func (s *Schema) DropStatements() Statements {
+	return s.dropStatements(defaultFormat())
+}
+
+func (s *Schema) dropStatements(f *format) Statements {
+	var ss Statements
+	for _, t := range s.tables {
+		for _, g := range t.grants {
+			ss = append(ss, g.revokeStatement(f))
+		}
+	}
+	for i := len(s.roles) - 1; i >= 0; i-- {
+		ss = append(ss, s.roles[i].dropRoleStatement(f))
+	}
+	indexes := s.Indexes()
+	for i := len(indexes) - 1; i >= 0; i-- {
+		ss = append(ss, indexes[i].dropIndexStatement(f))
+	}
+	for i := len(s.tables) - 1; i >= 0; i-- {
+		ss = append(ss, s.tables[i].dropTableStatement(f))
+	}
+	for i := len(s.localityGroups) - 1; i >= 0; i-- {
+		ss = append(ss, s.localityGroups[i].DropLocalityGroupStatement())
+	}
+	for i := len(s.sequences) - 1; i >= 0; i-- {
+		ss = append(ss, s.sequences[i].dropSequenceStatement(f))
+	}
+	nss := s.NamedSchemas()
+	for i := len(nss) - 1; i >= 0; i-- {
+		ss = append(ss, nss[i].dropNamedSchemaStatement(f))
+	}
+
+	return ss
+}
+
+// DDL returns the whole schema as a string slices.
+// See Statements for the order of the output.
+func (s *Schema) DDL() []string {
+	return s.Statements().SQL()
+}
+
+// Script returns the whole schema as one DDL script.
+func (s *Schema) Script() string {
+	ss := s.DDL()
+	for i := range ss {
+		ss[i] = Semicolon(ss[i])
+	}
+
+	return strings.Join(ss, "\n\n")
+}
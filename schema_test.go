@@ -0,0 +1,129 @@
+package spoon_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+type SchemaParent struct {
+	ID   int64  `db:"pk"`
+	Name string `db:"index=SchemaParentByName"`
+}
+
+func (s *SchemaParent) TableName() string {
+	return "SchemaParent"
+}
+
+type SchemaChild struct {
+	ID      int64 `db:"pk=1,interleave=SchemaParent"`
+	ChildID int64 `db:"pk=2"`
+}
+
+func (s *SchemaChild) TableName() string {
+	return "SchemaChild"
+}
+
+type SchemaInvalidChild struct {
+	ChildID int64 `db:"pk=1,interleave=SchemaParent"`
+}
+
+func (s *SchemaInvalidChild) TableName() string {
+	return "SchemaInvalidChild"
+}
+
+type SchemaInvalidIndex struct {
+	ID int64 `db:"pk"`
+}
+
+func (s *SchemaInvalidIndex) TableName() string {
+	return "SchemaInvalidIndex"
+}
+
+func (s *SchemaInvalidIndex) Indexes() spoon.Indexes {
+	return spoon.Indexes{
+		spoon.AddIndex("SchemaInvalidIndexByName", "SchemaInvalidIndex", false, spoon.KeyPart{ColumnName: "Name"}),
+	}
+}
+
+func TestBuildSchema(t *testing.T) {
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	s, err := cli.BuildSchema([]spoon.EntityBehavior{spoon.Entity(&SchemaChild{}), spoon.Entity(&SchemaParent{})})
+	if err != nil {
+		t.Fatalf("error build schema %#v", err)
+	}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("error validate %#v", err)
+	}
+
+	var names []string
+	for _, tbl := range s.Tables() {
+		names = append(names, tbl.Name())
+	}
+	if diff := cmp.Diff([]string{"SchemaParent", "SchemaChild"}, names); diff != "" {
+		t.Errorf("Tables Diff:\n%s", diff)
+	}
+
+	child := s.Table("SchemaChild")
+	if child == nil {
+		t.Fatalf("table SchemaChild is not found")
+	}
+	if diff := cmp.Diff("SchemaParent", child.PrimaryKey().InterleavedTableName()); diff != "" {
+		t.Errorf("InterleavedTableName Diff:\n%s", diff)
+	}
+	if col := child.Column("ChildID"); col == nil || col.SpannerType() != "INT64" || col.IsNullable() {
+		t.Errorf("unexpected column ChildID %#v", col)
+	}
+	if idx := s.Index("SchemaParentByName"); idx == nil || idx.TableName() != "SchemaParent" {
+		t.Errorf("unexpected index SchemaParentByName %#v", idx)
+	}
+
+	expect := "CREATE TABLE `SchemaParent` (\n    `ID` INT64 NOT NULL,\n    `Name` STRING(MAX) NOT NULL,\n) PRIMARY KEY (`ID`);\n\n" +
+		"CREATE TABLE `SchemaChild` (\n    `ID` INT64 NOT NULL,\n    `ChildID` INT64 NOT NULL,\n) PRIMARY KEY (`ID`, `ChildID`), INTERLEAVE IN PARENT `SchemaParent`;\n\n" +
+		"CREATE INDEX `SchemaParentByName` ON `SchemaParent` (`Name`);"
+	if diff := cmp.Diff(expect, s.Script()); diff != "" {
+		t.Errorf("Script Diff:\n%s", diff)
+	}
+}
+
+func TestSchema_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		entities []spoon.EntityBehavior
+	}{
+		{
+			name:     "1 parent does not exist",
+			entities: []spoon.EntityBehavior{spoon.Entity(&SchemaChild{})},
+		},
+		{
+			name:     "2 primary key does not start with the parent key",
+			entities: []spoon.EntityBehavior{spoon.Entity(&SchemaParent{}), spoon.Entity(&SchemaInvalidChild{})},
+		},
+		{
+			name:     "3 index column does not exist",
+			entities: []spoon.EntityBehavior{spoon.Entity(&SchemaInvalidIndex{})},
+		},
+	}
+
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := cli.BuildSchema(tt.entities)
+			if err != nil {
+				t.Fatalf("error build schema %#v", err)
+			}
+			if err := s.Validate(); err == nil {
+				t.Errorf("expect error, but nil")
+			}
+		})
+	}
+}
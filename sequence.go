@@ -0,0 +1,119 @@
+package spoon
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	bitReversedPositive = "bit_reversed_positive"
+)
+
+// Sequences are alias of sequence slices.
+type Sequences []*Sequence
+
+// Sequence holds the necessary information to construct Sequence.
+type Sequence struct {
+	name             string
+	kind             string
+	hasSkipRange     bool
+	skipRangeMin     int64
+	skipRangeMax     int64
+	startWithCounter int64
+}
+
+// SequenceOption sets the optional value of Sequence.
+type SequenceOption func(*Sequence)
+
+// SkipRange sets the range of values that the sequence never generates.
+func SkipRange(min, max int64) SequenceOption {
+	return func(s *Sequence) {
+		s.hasSkipRange = true
+		s.skipRangeMin = min
+		s.skipRangeMax = max
+	}
+}
+
+// StartWithCounter sets the initial value of the internal counter.
+func StartWithCounter(counter int64) SequenceOption {
+	return func(s *Sequence) {
+		s.startWithCounter = counter
+	}
+}
+
+// AddSequence creates bit-reversed positive Sequence.
+func AddSequence(name string, opts ...SequenceOption) *Sequence {
+	s := &Sequence{
+		name: name,
+		kind: bitReversedPositive,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Name returns the sequence name.
+func (s *Sequence) Name() string {
+	return s.name
+}
+
+// CreateSequenceSchema return `CREATE SEQUENCE` schema.
+func (s *Sequence) CreateSequenceSchema(opts ...FormatOption) string {
+	return s.createSequenceSchema(newFormat(opts))
+}
+
+func (s *Sequence) createSequenceSchema(f *format) string {
+	if f.dialect == PostgreSQL {
+		return s.createSequenceSchemaPostgreSQL(f)
+	}
+
+	opts := []string{fmt.Sprintf("sequence_kind = '%s'", s.kind)}
+	if s.hasSkipRange {
+		opts = append(opts, fmt.Sprintf("skip_range_min = %d", s.skipRangeMin), fmt.Sprintf("skip_range_max = %d", s.skipRangeMax))
+	}
+	if s.startWithCounter != 0 {
+		opts = append(opts, fmt.Sprintf("start_with_counter = %d", s.startWithCounter))
+	}
+
+	return fmt.Sprintf("%s %s OPTIONS (%s)", f.createClause("SEQUENCE"), f.quote(s.name), strings.Join(opts, ", "))
+}
+
+func (s *Sequence) createSequenceSchemaPostgreSQL(f *format) string {
+	words := []string{f.createClause("SEQUENCE"), f.quote(s.name), strings.ToUpper(s.kind)}
+	if s.hasSkipRange {
+		words = append(words, fmt.Sprintf("SKIP RANGE %d %d", s.skipRangeMin, s.skipRangeMax))
+	}
+	if s.startWithCounter != 0 {
+		words = append(words, fmt.Sprintf("START COUNTER WITH %d", s.startWithCounter))
+	}
+
+	return strings.Join(words, " ")
+}
+
+// AlterSequenceSchema return `ALTER SEQUENCE` schema.
+// The skip range is reset to null when it is not set.
+func (s *Sequence) AlterSequenceSchema() string {
+	opts := make([]string, 0, 3)
+	if s.hasSkipRange {
+		opts = append(opts, fmt.Sprintf("skip_range_min = %d", s.skipRangeMin), fmt.Sprintf("skip_range_max = %d", s.skipRangeMax))
+	} else {
+		opts = append(opts, "skip_range_min = null", "skip_range_max = null")
+	}
+	if s.startWithCounter != 0 {
+		opts = append(opts, fmt.Sprintf("start_with_counter = %d", s.startWithCounter))
+	}
+
+	return fmt.Sprintf("ALTER SEQUENCE %s SET OPTIONS (%s)", Quote(s.name), strings.Join(opts, ", "))
+}
+
+// DropSequenceSchema return `DROP SEQUENCE` schema.
+func (s *Sequence) DropSequenceSchema(opts ...FormatOption) string {
+	return s.dropSequenceSchema(newFormat(opts))
+}
+
+func (s *Sequence) dropSequenceSchema(f *format) string {
+	return fmt.Sprintf("%s %s", f.dropClause("SEQUENCE"), f.quote(s.name))
+}
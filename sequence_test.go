@@ -0,0 +1,47 @@
+package spoon_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+func TestAddSequence(t *testing.T) {
+	tests := []struct {
+		name         string
+		seq          *spoon.Sequence
+		expectCreate string
+		expectAlter  string
+		expectDrop   string
+	}{
+		{
+			name:         "1 no option",
+			seq:          spoon.AddSequence("UserSeq"),
+			expectCreate: "CREATE SEQUENCE `UserSeq` OPTIONS (sequence_kind = 'bit_reversed_positive')",
+			expectAlter:  "ALTER SEQUENCE `UserSeq` SET OPTIONS (skip_range_min = null, skip_range_max = null)",
+			expectDrop:   "DROP SEQUENCE `UserSeq`",
+		},
+		{
+			name:         "2 skip range and start with counter",
+			seq:          spoon.AddSequence("UserSeq", spoon.SkipRange(1, 1000), spoon.StartWithCounter(50)),
+			expectCreate: "CREATE SEQUENCE `UserSeq` OPTIONS (sequence_kind = 'bit_reversed_positive', skip_range_min = 1, skip_range_max = 1000, start_with_counter = 50)",
+			expectAlter:  "ALTER SEQUENCE `UserSeq` SET OPTIONS (skip_range_min = 1, skip_range_max = 1000, start_with_counter = 50)",
+			expectDrop:   "DROP SEQUENCE `UserSeq`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.expectCreate, tt.seq.CreateSequenceSchema()); diff != "" {
+				t.Errorf("CreateSequenceSchema Diff:\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.expectAlter, tt.seq.AlterSequenceSchema()); diff != "" {
+				t.Errorf("AlterSequenceSchema Diff:\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.expectDrop, tt.seq.DropSequenceSchema()); diff != "" {
+				t.Errorf("DropSequenceSchema Diff:\n%s", diff)
+			}
+		})
+	}
+}
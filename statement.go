@@ -0,0 +1,216 @@
+package spoon
+
+// StatementKind is the kind of DDL statement.
+type StatementKind string
+
+const (
+	StatementCreate StatementKind = "CREATE"
+	StatementAlter  StatementKind = "ALTER"
+	StatementDrop   StatementKind = "DROP"
+	StatementGrant  StatementKind = "GRANT"
+	StatementRevoke StatementKind = "REVOKE"
+)
+
+// ObjectType is the type of the object that DDL statement targets.
+type ObjectType string
+
+const (
+	ObjectDatabase      ObjectType = "DATABASE"
+	ObjectNamedSchema   ObjectType = "SCHEMA"
+	ObjectSequence      ObjectType = "SEQUENCE"
+	ObjectLocalityGroup ObjectType = "LOCALITY GROUP"
+	ObjectTable         ObjectType = "TABLE"
+	ObjectIndex         ObjectType = "INDEX"
+	ObjectRole          ObjectType = "ROLE"
+	ObjectPropertyGraph ObjectType = "PROPERTY GRAPH"
+)
+
+// Statement holds DDL statement and its properties.
+type Statement struct {
+	Kind       StatementKind
+	ObjectType ObjectType
+	// Object is the name of the target object.
+	Object string
+	// Table is the name of the table that the statement touches, or empty string if it touches no table.
+	Table string
+	// Destructive reports whether the statement may lose data or break the access.
+	Destructive bool
+	// Backfill reports whether the statement causes a long running backfill such as `CREATE INDEX`,
+	// or the validation of the existing values such as `ALTER COLUMN` to NOT NULL.
+	Backfill bool
+	SQL      string
+}
+
+// Statements are alias of statement slices.
+type Statements []*Statement
+
+// SQL returns the DDL strings of the statements.
+func (ss Statements) SQL() []string {
+	sqls := make([]string, 0, len(ss))
+	for _, s := range ss {
+		sqls = append(sqls, s.SQL)
+	}
+
+	return sqls
+}
+
+// Filter returns the statements that satisfy f.
+func (ss Statements) Filter(f func(*Statement) bool) Statements {
+	var filtered Statements
+	for _, s := range ss {
+		if f(s) {
+			filtered = append(filtered, s)
+		}
+	}
+
+	return filtered
+}
+
+// HasDestructive reports whether any statement is destructive.
+func (ss Statements) HasDestructive() bool {
+	for _, s := range ss {
+		if s.Destructive {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasBackfill reports whether any statement causes a backfill.
+func (ss Statements) HasBackfill() bool {
+	for _, s := range ss {
+		if s.Backfill {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CreateTableStatement return `CREATE TABLE` statement.
+func (t *Table) CreateTableStatement(opts ...FormatOption) *Statement {
+	return t.createTableStatement(newFormat(opts))
+}
+
+func (t *Table) createTableStatement(f *format) *Statement {
+	return &Statement{Kind: StatementCreate, ObjectType: ObjectTable, Object: t.name, Table: t.name, SQL: t.createTableSchema(f)}
+}
+
+// DropTableStatement return `DROP TABLE` statement.
+func (t *Table) DropTableStatement(opts ...FormatOption) *Statement {
+	return t.dropTableStatement(newFormat(opts))
+}
+
+func (t *Table) dropTableStatement(f *format) *Statement {
+	return &Statement{Kind: StatementDrop, ObjectType: ObjectTable, Object: t.name, Table: t.name, Destructive: true, SQL: t.dropTableSchema(f)}
+}
+
+// CreateIndexStatement return `CREATE INDEX` statement.
+func (i *Index) CreateIndexStatement(opts ...FormatOption) *Statement {
+	return i.createIndexStatement(newFormat(opts))
+}
+
+func (i *Index) createIndexStatement(f *format) *Statement {
+	return &Statement{Kind: StatementCreate, ObjectType: ObjectIndex, Object: i.name, Table: i.tableName, Backfill: true, SQL: i.createIndexSchema(f)}
+}
+
+// DropIndexStatement return `DROP INDEX` statement.
+func (i *Index) DropIndexStatement(opts ...FormatOption) *Statement {
+	return i.dropIndexStatement(newFormat(opts))
+}
+
+func (i *Index) dropIndexStatement(f *format) *Statement {
+	return &Statement{Kind: StatementDrop, ObjectType: ObjectIndex, Object: i.name, Table: i.tableName, Destructive: true, SQL: i.dropIndexSchema(f)}
+}
+
+// CreateSequenceStatement return `CREATE SEQUENCE` statement.
+func (s *Sequence) CreateSequenceStatement(opts ...FormatOption) *Statement {
+	return s.createSequenceStatement(newFormat(opts))
+}
+
+func (s *Sequence) createSequenceStatement(f *format) *Statement {
+	return &Statement{Kind: StatementCreate, ObjectType: ObjectSequence, Object: s.name, SQL: s.createSequenceSchema(f)}
+}
+
+// DropSequenceStatement return `DROP SEQUENCE` statement.
+func (s *Sequence) DropSequenceStatement(opts ...FormatOption) *Statement {
+	return s.dropSequenceStatement(newFormat(opts))
+}
+
+func (s *Sequence) dropSequenceStatement(f *format) *Statement {
+	return &Statement{Kind: StatementDrop, ObjectType: ObjectSequence, Object: s.name, Destructive: true, SQL: s.dropSequenceSchema(f)}
+}
+
+// CreateLocalityGroupStatement return `CREATE LOCALITY GROUP` statement.
+func (lg *LocalityGroup) CreateLocalityGroupStatement() *Statement {
+	return &Statement{Kind: StatementCreate, ObjectType: ObjectLocalityGroup, Object: lg.name, SQL: lg.CreateLocalityGroupSchema()}
+}
+
+// DropLocalityGroupStatement return `DROP LOCALITY GROUP` statement.
+func (lg *LocalityGroup) DropLocalityGroupStatement() *Statement {
+	return &Statement{Kind: StatementDrop, ObjectType: ObjectLocalityGroup, Object: lg.name, Destructive: true, SQL: lg.DropLocalityGroupSchema()}
+}
+
+// CreateNamedSchemaStatement return `CREATE SCHEMA` statement.
+func (s *NamedSchema) CreateNamedSchemaStatement() *Statement {
+	return s.createNamedSchemaStatement(defaultFormat())
+}
+
+func (s *NamedSchema) createNamedSchemaStatement(f *format) *Statement {
+	return &Statement{Kind: StatementCreate, ObjectType: ObjectNamedSchema, Object: s.name, SQL: s.createNamedSchemaSchema(f)}
+}
+
+// DropNamedSchemaStatement return `DROP SCHEMA` statement.
+func (s *NamedSchema) DropNamedSchemaStatement() *Statement {
+	return s.dropNamedSchemaStatement(defaultFormat())
+}
+
+func (s *NamedSchema) dropNamedSchemaStatement(f *format) *Statement {
+	return &Statement{Kind: StatementDrop, ObjectType: ObjectNamedSchema, Object: s.name, Destructive: true, SQL: s.dropNamedSchemaSchema(f)}
+}
+
+// CreateRoleStatement return `CREATE ROLE` statement.
+func (r *Role) CreateRoleStatement() *Statement {
+	return r.createRoleStatement(defaultFormat())
+}
+
+func (r *Role) createRoleStatement(f *format) *Statement {
+	return &Statement{Kind: StatementCreate, ObjectType: ObjectRole, Object: r.name, SQL: r.createRoleSchema(f)}
+}
+
+// DropRoleStatement return `DROP ROLE` statement.
+func (r *Role) DropRoleStatement() *Statement {
+	return r.dropRoleStatement(defaultFormat())
+}
+
+func (r *Role) dropRoleStatement(f *format) *Statement {
+	return &Statement{Kind: StatementDrop, ObjectType: ObjectRole, Object: r.name, Destructive: true, SQL: r.dropRoleSchema(f)}
+}
+
+// GrantStatement return `GRANT` statement.
+func (g *Grant) GrantStatement() *Statement {
+	return g.grantStatement(defaultFormat())
+}
+
+func (g *Grant) grantStatement(f *format) *Statement {
+	return &Statement{Kind: StatementGrant, ObjectType: ObjectRole, Object: g.roleName, Table: g.tableName, SQL: g.grantSchema(f)}
+}
+
+// RevokeStatement return `REVOKE` statement.
+func (g *Grant) RevokeStatement() *Statement {
+	return g.revokeStatement(defaultFormat())
+}
+
+func (g *Grant) revokeStatement(f *format) *Statement {
+	return &Statement{Kind: StatementRevoke, ObjectType: ObjectRole, Object: g.roleName, Table: g.tableName, Destructive: true, SQL: g.revokeSchema(f)}
+}
+
+// alterDatabaseStatement return `ALTER DATABASE` statement, or nil if no option is set.
+func alterDatabaseStatement(dbName, sql string) *Statement {
+	if sql == "" {
+		return nil
+	}
+
+	return &Statement{Kind: StatementAlter, ObjectType: ObjectDatabase, Object: dbName, SQL: sql}
+}
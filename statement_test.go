@@ -0,0 +1,78 @@
+package spoon_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+func TestGenerateStatements(t *testing.T) {
+	cli, err := spoon.New(
+		spoon.WithSequences(spoon.AddSequence("Test3Seq")),
+		spoon.WithRoles(spoon.AddRole("analyst"), spoon.AddRole("writer")),
+	)
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+	ebs := []spoon.EntityBehavior{&Test3{}, &Test5{}}
+
+	ss, err := cli.GenerateStatements(ebs)
+	if err != nil {
+		t.Fatalf("error generate statements %#v", err)
+	}
+
+	type summary struct {
+		Kind        spoon.StatementKind
+		ObjectType  spoon.ObjectType
+		Object      string
+		Table       string
+		Destructive bool
+		Backfill    bool
+	}
+	summarize := func(ss spoon.Statements) []summary {
+		var sums []summary
+		for _, s := range ss {
+			sums = append(sums, summary{s.Kind, s.ObjectType, s.Object, s.Table, s.Destructive, s.Backfill})
+		}
+		return sums
+	}
+
+	expect := []summary{
+		{spoon.StatementCreate, spoon.ObjectSequence, "Test3Seq", "", false, false},
+		{spoon.StatementCreate, spoon.ObjectTable, "Test3", "Test3", false, false},
+		{spoon.StatementCreate, spoon.ObjectTable, "Test5", "Test5", false, false},
+		{spoon.StatementCreate, spoon.ObjectIndex, "Test3ByName", "Test3", false, true},
+		{spoon.StatementCreate, spoon.ObjectRole, "analyst", "", false, false},
+		{spoon.StatementCreate, spoon.ObjectRole, "writer", "", false, false},
+		{spoon.StatementGrant, spoon.ObjectRole, "analyst", "Test5", false, false},
+		{spoon.StatementGrant, spoon.ObjectRole, "writer", "Test5", false, false},
+	}
+	if diff := cmp.Diff(expect, summarize(ss)); diff != "" {
+		t.Errorf("GenerateStatements Diff:\n%s", diff)
+	}
+	if ss.HasDestructive() || !ss.HasBackfill() {
+		t.Errorf("unexpected HasDestructive=%v HasBackfill=%v", ss.HasDestructive(), ss.HasBackfill())
+	}
+
+	drops, err := cli.GenerateDropStatements(ebs)
+	if err != nil {
+		t.Fatalf("error generate drop statements %#v", err)
+	}
+	expectDrops := []string{
+		"REVOKE SELECT(`ID`, `Name`) ON TABLE `Test5` FROM ROLE `analyst`",
+		"REVOKE INSERT ON TABLE `Test5` FROM ROLE `writer`",
+		"DROP ROLE `writer`",
+		"DROP ROLE `analyst`",
+		"DROP INDEX `Test3ByName`",
+		"DROP TABLE `Test5`",
+		"DROP TABLE `Test3`",
+		"DROP SEQUENCE `Test3Seq`",
+	}
+	if diff := cmp.Diff(expectDrops, drops.SQL()); diff != "" {
+		t.Errorf("GenerateDropStatements Diff:\n%s", diff)
+	}
+	if len(drops.Filter(func(s *spoon.Statement) bool { return !s.Destructive })) != 0 {
+		t.Errorf("expect all drop statements are destructive")
+	}
+}
@@ -0,0 +1,68 @@
+// Package static parses the Entity from the Go source without reflection,
+// so the schema is built without building and running the application.
+package static
+
+import (
+	"github.com/pi9min/spoon"
+	"github.com/pi9min/spoon/internal/bridge"
+	"github.com/pi9min/spoon/internal/source"
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
+)
+
+// LoadTables parses the Entity declared in the packages matched by the patterns with the options of the client.
+// The patterns are resolved in dir, and the types that have the TableName method are treated as Entity.
+//
+// The columns are read from the struct fields and tags, and the methods such as TableName, PrimaryKey and Indexes
+// must consist of one return statement of constant values and spoon functions such as AddPrimaryKey and AddIndex.
+// The field of the struct variable of spoon.ColumnName, such as `UserColumns.ID` generated by spoongen,
+// is read as the column of the field name.
+func LoadTables(c *spoon.Client, dir string, patterns ...string) ([]*spoon.Table, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Dir:  dir,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, errors.Wrap(err, "load packages")
+	}
+
+	p := bridge.ClientParser(c)
+	var tables []*spoon.Table
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) != 0 {
+			return nil, errors.Errorf("package %s: %v", pkg.PkgPath, pkg.Errors[0])
+		}
+
+		sp := source.NewParser(p, pkg.Fset, pkg.Types, pkg.TypesInfo, pkg.Syntax)
+		for _, tn := range sp.Entities() {
+			t, err := sp.Parse(tn)
+			if err != nil {
+				return nil, err
+			}
+			tables = append(tables, t)
+		}
+	}
+
+	return tables, nil
+}
+
+// BuildSchema returns Schema of the Entity declared in the packages matched by the patterns.
+// See LoadTables for how the Entity is parsed.
+func BuildSchema(c *spoon.Client, dir string, patterns ...string) (*spoon.Schema, error) {
+	tables, err := LoadTables(c, dir, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := make([]any, 0, len(tables))
+	for _, t := range tables {
+		ts = append(ts, t)
+	}
+	s, err := bridge.NewSchema(c, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.(*spoon.Schema), nil
+}
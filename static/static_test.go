@@ -0,0 +1,70 @@
+package static_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+	example "github.com/pi9min/spoon/_example"
+	"github.com/pi9min/spoon/static"
+)
+
+func TestBuildSchema(t *testing.T) {
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new client: %v", err)
+	}
+
+	s, err := static.BuildSchema(cli, "..", "./_example")
+	if err != nil {
+		t.Fatalf("error static.BuildSchema: %v", err)
+	}
+	expect, err := cli.BuildSchema([]spoon.EntityBehavior{
+		&example.Balance{},
+		&example.Bookmark{},
+		&example.Entry{},
+		&example.NestParent{},
+		&example.PlayerComment{},
+		&example.User{},
+	})
+	if err != nil {
+		t.Fatalf("error BuildSchema: %v", err)
+	}
+
+	if diff := cmp.Diff(expect.Statements(), s.Statements()); diff != "" {
+		t.Errorf("Diff:\n%s", diff)
+	}
+}
+
+func TestLoadTables_Error(t *testing.T) {
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new client: %v", err)
+	}
+
+	tests := []struct {
+		pattern string
+		expect  string
+	}{
+		{
+			pattern: "./testdata/badtag",
+			expect:  "badtag.go:7:2: field Name: desc can only be used with pk",
+		},
+		{
+			pattern: "./testdata/nonconst",
+			expect:  "nonconst.go:14:9: unsupported call strings.ToUpper",
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := static.LoadTables(cli, ".", tt.pattern)
+		if err == nil {
+			t.Errorf("%s: expected error", tt.pattern)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("%s: error %q does not contain %q", tt.pattern, err.Error(), tt.expect)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package badtag
+
+import "github.com/pi9min/spoon"
+
+type BadTag struct {
+	ID   int64  `db:"pk"`
+	Name string `db:"desc"`
+}
+
+func (BadTag) TableName() string {
+	return "BadTag"
+}
+
+func (BadTag) PrimaryKey() *spoon.PrimaryKey {
+	return spoon.AddPrimaryKey(spoon.KeyPart{ColumnName: "ID"})
+}
@@ -0,0 +1,19 @@
+package nonconst
+
+import (
+	"strings"
+
+	"github.com/pi9min/spoon"
+)
+
+type NonConst struct {
+	ID int64
+}
+
+func (NonConst) TableName() string {
+	return strings.ToUpper("NonConst")
+}
+
+func (NonConst) PrimaryKey() *spoon.PrimaryKey {
+	return spoon.AddPrimaryKey(spoon.KeyPart{ColumnName: "ID"})
+}
@@ -7,10 +7,14 @@ import (
 
 // Table is mapping struct info
 type Table struct {
-	name       string
-	columns    []*Column
-	primaryKey *PrimaryKey
-	indexes    Indexes
+	name          string
+	schemaName    string
+	columns       []*Column
+	primaryKey    *PrimaryKey
+	indexes       Indexes
+	grants        Grants
+	localityGroup string
+	renamedFrom   string
 }
 
 func newTable(name string, columns []*Column, pk *PrimaryKey, indexes Indexes) *Table {
@@ -22,21 +26,191 @@ func newTable(name string, columns []*Column, pk *PrimaryKey, indexes Indexes) *
 	}
 }
 
+// Name returns the table name qualified by the named schema.
+func (t *Table) Name() string {
+	return t.name
+}
+
+// SchemaName returns the named schema of the table, or empty string in the default schema.
+func (t *Table) SchemaName() string {
+	return t.schemaName
+}
+
+// Columns returns the columns in order of the fields.
+func (t *Table) Columns() []*Column {
+	return append([]*Column(nil), t.columns...)
+}
+
+// PrimaryKey returns the primary key.
+func (t *Table) PrimaryKey() *PrimaryKey {
+	return t.primaryKey
+}
+
+// Indexes returns the indexes.
 func (t *Table) Indexes() Indexes {
 	return t.indexes
 }
 
-func (t *Table) CreateTableSchema() string {
-	ss := make([]string, 0, len(t.columns)+2)
-	ss = append(ss, fmt.Sprintf("CREATE TABLE %s (", Quote(t.name)))
-	for i := range t.columns {
-		c := t.columns[i]
-		ss = append(ss, fmt.Sprintf("    %s,", c.ToSQL()))
+// Grants returns the grants on the table.
+func (t *Table) Grants() Grants {
+	return t.grants
+}
+
+// LocalityGroup returns the locality group of the table.
+func (t *Table) LocalityGroup() string {
+	return t.localityGroup
+}
+
+// RenamedFrom returns the previous name of the table declared by PreviousTableName.
+func (t *Table) RenamedFrom() string {
+	return t.renamedFrom
+}
+
+// qualify places the table in the named schema.
+// The indexes and grants that are not qualified are resolved in the same schema.
+// The interleaved parent is left as declared, and resolved by resolveParent when the whole schema is known.
+func (t *Table) qualify(schemaName string) {
+	if i := strings.LastIndex(t.name, "."); i >= 0 {
+		schemaName = t.name[:i]
+	}
+	if schemaName == "" {
+		return
+	}
+
+	t.schemaName = schemaName
+	t.name = qualify(schemaName, t.name)
+	if t.renamedFrom != "" {
+		t.renamedFrom = qualify(schemaName, t.renamedFrom)
+	}
+
+	indexes := make(Indexes, 0, len(t.indexes))
+	for _, idx := range t.indexes {
+		i := *idx
+		i.name = qualify(schemaName, i.name)
+		i.tableName = qualify(schemaName, i.tableName)
+		indexes = append(indexes, &i)
+	}
+	t.indexes = indexes
+
+	grants := make(Grants, 0, len(t.grants))
+	for _, g := range t.grants {
+		gg := *g
+		gg.tableName = qualify(schemaName, gg.tableName)
+		grants = append(grants, &gg)
+	}
+	t.grants = grants
+}
+
+// resolveParent resolves the interleaved parent that is not qualified to the table of the same name
+// in the named schema of the table, if the named schema has it. Otherwise it stays in the default schema.
+func (t *Table) resolveParent(byName map[string]*Table) {
+	if t.schemaName == "" || t.primaryKey == nil {
+		return
+	}
+	parent := t.primaryKey.interleavedTableName
+	if parent == "" || strings.Contains(parent, ".") {
+		return
+	}
+	name := qualify(t.schemaName, parent)
+	if _, ok := byName[name]; !ok {
+		return
+	}
+
+	pk := *t.primaryKey
+	pk.interleavedTableName = name
+	t.primaryKey = &pk
+}
+
+// Column returns the column of the name, or nil if it does not exist.
+func (t *Table) Column(name string) *Column {
+	for _, c := range t.columns {
+		if c.name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// CreateTableSchema return `CREATE TABLE` schema.
+func (t *Table) CreateTableSchema(opts ...FormatOption) string {
+	return t.createTableSchema(newFormat(opts))
+}
+
+func (t *Table) createTableSchema(f *format) string {
+	defs := make([]string, 0, len(t.columns)+1)
+	for _, c := range t.columns {
+		defs = append(defs, c.toSQL(f))
+	}
+	// The primary key is declared in the column list in PostgreSQL dialect.
+	if f.dialect == PostgreSQL {
+		defs = append(defs, t.primaryKey.keySQL(f))
+	}
+
+	ss := make([]string, 0, len(defs)+2)
+	ss = append(ss, fmt.Sprintf("%s %s (", f.createClause("TABLE"), f.quote(t.name)))
+	for i, def := range defs {
+		if i == len(defs)-1 && (!f.trailingComma || f.dialect == PostgreSQL) {
+			ss = append(ss, fmt.Sprintf("%s%s", f.indentString(), def))
+			continue
+		}
+		ss = append(ss, fmt.Sprintf("%s%s,", f.indentString(), def))
+	}
+
+	if f.dialect == PostgreSQL {
+		if t.primaryKey.interleavedTableName != "" {
+			ss = append(ss, ") "+t.primaryKey.interleaveSQL(f))
+		} else {
+			ss = append(ss, ")")
+		}
+		return strings.Join(ss, "\n")
+	}
+
+	pk := t.primaryKey.keySQL(f)
+	if t.primaryKey.interleavedTableName != "" {
+		pk += ", " + t.primaryKey.interleaveSQL(f)
+	}
+	if t.localityGroup != "" {
+		pk += ", " + localityGroupOption(t.localityGroup)
 	}
-	ss = append(ss, fmt.Sprintf(") %s", t.primaryKey.ToSQL()))
+	ss = append(ss, fmt.Sprintf(") %s", pk))
 	return strings.Join(ss, "\n")
 }
 
-func (t *Table) DropTableSchema() string {
-	return fmt.Sprintf("DROP TABLE %s", Quote(t.name))
+// AlterLocalityGroupSchemas return `ALTER TABLE` schemas that set the locality group of the table and its columns.
+func (t *Table) AlterLocalityGroupSchemas() []string {
+	var ss []string
+	if t.localityGroup != "" {
+		ss = append(ss, fmt.Sprintf("ALTER TABLE %s SET %s", Quote(t.name), localityGroupOption(t.localityGroup)))
+	}
+	for _, c := range t.columns {
+		if c.localityGroup != "" {
+			ss = append(ss, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET %s", Quote(t.name), Quote(c.name), localityGroupOption(c.localityGroup)))
+		}
+	}
+
+	return ss
+}
+
+func (t *Table) localityGroups() []string {
+	var lgs []string
+	if t.localityGroup != "" {
+		lgs = append(lgs, t.localityGroup)
+	}
+	for _, c := range t.columns {
+		if c.localityGroup != "" {
+			lgs = append(lgs, c.localityGroup)
+		}
+	}
+
+	return lgs
+}
+
+// DropTableSchema return `DROP TABLE` schema.
+func (t *Table) DropTableSchema(opts ...FormatOption) string {
+	return t.dropTableSchema(newFormat(opts))
+}
+
+func (t *Table) dropTableSchema(f *format) string {
+	return fmt.Sprintf("%s %s", f.dropClause("TABLE"), f.quote(t.name))
 }
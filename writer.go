@@ -0,0 +1,67 @@
+package spoon
+
+import (
+	"io"
+	"strings"
+)
+
+// WriteSchema writes the whole schema of the specified Entity to w.
+// See Schema.Statements for the order of the output.
+func (c *Client) WriteSchema(w io.Writer, ebs []EntityBehavior, opts ...FormatOption) error {
+	s, err := c.BuildSchema(ebs)
+	if err != nil {
+		return err
+	}
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	f := c.format(opts...)
+	if err := s.validateDialect(f.dialect); err != nil {
+		return err
+	}
+
+	return writeStatements(w, s.statements(f), f)
+}
+
+// WriteDropSchema writes the statements that drop the whole schema of the specified Entity to w.
+func (c *Client) WriteDropSchema(w io.Writer, ebs []EntityBehavior, opts ...FormatOption) error {
+	s, err := c.BuildSchema(ebs)
+	if err != nil {
+		return err
+	}
+
+	f := c.format(opts...)
+	if err := s.validateDialect(f.dialect); err != nil {
+		return err
+	}
+
+	return writeStatements(w, s.dropStatements(f), f)
+}
+
+// WriteStatements writes the statements to w.
+func WriteStatements(w io.Writer, ss Statements, opts ...FormatOption) error {
+	return writeStatements(w, ss, newFormat(opts))
+}
+
+func writeStatements(w io.Writer, ss Statements, f *format) error {
+	if _, err := io.WriteString(w, f.headerLines()); err != nil {
+		return err
+	}
+
+	sep := "\n" + strings.Repeat("\n", f.blankLines)
+	for i, s := range ss {
+		str := s.SQL + f.terminator
+		if i < len(ss)-1 {
+			str += sep
+		} else {
+			str += "\n"
+		}
+
+		if _, err := io.WriteString(w, str); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
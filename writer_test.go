@@ -0,0 +1,70 @@
+package spoon_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pi9min/spoon"
+)
+
+func TestWriteSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []spoon.FormatOption
+		expect string
+	}{
+		{
+			name: "1 default",
+			expect: "CREATE TABLE `Test1` (\n    `ID` INT64 NOT NULL,\n    `Name` STRING(MAX) NOT NULL,\n    `CreatedAt` TIMESTAMP NOT NULL,\n    `UpdatedAt` TIMESTAMP NOT NULL,\n) PRIMARY KEY (`ID`);\n\n" +
+				"CREATE INDEX `Test1ByCreatedAtDesc` ON `Test1` (`CreatedAt` DESC);\n",
+		},
+		{
+			name: "2 all options",
+			opts: []spoon.FormatOption{
+				spoon.Header("generated\nby spoon"),
+				spoon.Terminator(""),
+				spoon.BlankLines(0),
+				spoon.IndentWidth(2),
+				spoon.TrailingComma(false),
+				spoon.IfNotExists(true),
+			},
+			expect: "-- generated\n-- by spoon\n" +
+				"CREATE TABLE IF NOT EXISTS `Test1` (\n  `ID` INT64 NOT NULL,\n  `Name` STRING(MAX) NOT NULL,\n  `CreatedAt` TIMESTAMP NOT NULL,\n  `UpdatedAt` TIMESTAMP NOT NULL\n) PRIMARY KEY (`ID`)\n" +
+				"CREATE INDEX IF NOT EXISTS `Test1ByCreatedAtDesc` ON `Test1` (`CreatedAt` DESC)\n",
+		},
+	}
+
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			if err := cli.WriteSchema(buf, []spoon.EntityBehavior{&Test1{}}, tt.opts...); err != nil {
+				t.Fatalf("error write schema %#v", err)
+			}
+			if diff := cmp.Diff(tt.expect, buf.String()); diff != "" {
+				t.Errorf("WriteSchema Diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteDropSchema(t *testing.T) {
+	cli, err := spoon.New()
+	if err != nil {
+		t.Fatalf("error new Client")
+	}
+
+	buf := &bytes.Buffer{}
+	if err := cli.WriteDropSchema(buf, []spoon.EntityBehavior{&Test1{}}, spoon.IfExists(true)); err != nil {
+		t.Fatalf("error write drop schema %#v", err)
+	}
+	expect := "DROP INDEX IF EXISTS `Test1ByCreatedAtDesc`;\n\nDROP TABLE IF EXISTS `Test1`;\n"
+	if diff := cmp.Diff(expect, buf.String()); diff != "" {
+		t.Errorf("WriteDropSchema Diff:\n%s", diff)
+	}
+}